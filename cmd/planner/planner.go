@@ -4,14 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
 	"os/signal"
 	"planner-go/internal/api"
 	"planner-go/internal/api/spec"
-	"planner-go/internal/mailer/mailpit"
+	"planner-go/internal/digest"
+	"planner-go/internal/exchange"
+	"planner-go/internal/i18n"
+	"planner-go/internal/magiclink"
+	"planner-go/internal/mailer/smtp"
+	"planner-go/internal/mailer/smtpmailer"
+	"planner-go/internal/mailer/templates"
+	"planner-go/internal/outbox"
+	"planner-go/internal/pgstore"
+	"planner-go/internal/reminder"
 	"syscall"
 	"time"
+	// trips carry IANA time zones, which must load where the system has no
+	// tz database
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
@@ -66,10 +79,128 @@ func run(ctx context.Context) error {
 		return err
 	}
 
-	si := api.NewApi(pool, logger, mailpit.NewMailpit(pool))
+	secret := os.Getenv("PLANNER_TOKEN_SECRET")
+	if secret == "" {
+		return errors.New("PLANNER_TOKEN_SECRET must be set")
+	}
+
+	baseURL := os.Getenv("PLANNER_PUBLIC_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+
+	// rates in PLANNER_EXCHANGE_RATES_FILE are stored before serving, so
+	// budgets can be converted without reaching any rate source
+	if path := os.Getenv("PLANNER_EXCHANGE_RATES_FILE"); path != "" {
+		rates, err := exchange.LoadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err := pgstore.New(pool).ImportExchangeRates(ctx, pool, rates); err != nil {
+			return err
+		}
+
+		logger.Info("Imported exchange rates", zap.Int("rates", len(rates)), zap.String("file", path))
+	}
+
+	signer := magiclink.NewSigner([]byte(secret))
+	// PLANNER_INBOUND_SECRET enables the endpoint the mail server posts
+	// invitation replies to
+	si := api.NewApi(pool, logger, signer, baseURL, os.Getenv("PLANNER_INBOUND_SECRET"))
 	r := chi.NewMux()
-	r.Use(middleware.RequestID, middleware.Recoverer)
-	r.Mount("/", spec.Handler(&si))
+	r.Use(middleware.RequestID, middleware.Recoverer, i18n.Middleware, si.Authenticate)
+	r.NotFound(si.NotFound)
+	r.MethodNotAllowed(si.MethodNotAllowed)
+	r.Mount("/", spec.Handler(&si, spec.WithErrorHandler(si.ParamError)))
+
+	smtpConfig, err := smtp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	transport, err := smtp.NewTransport(smtpConfig)
+	if err != nil {
+		return err
+	}
+
+	defer transport.Close()
+
+	// files in PLANNER_MAIL_TEMPLATES_DIR replace the embedded ones of the same name
+	var override fs.FS
+	if dir := os.Getenv("PLANNER_MAIL_TEMPLATES_DIR"); dir != "" {
+		override = os.DirFS(dir)
+	}
+
+	mailTemplates, err := templates.Load(override)
+	if err != nil {
+		return err
+	}
+
+	mailer := smtpmailer.NewMailer(pool, signer, baseURL, transport, mailTemplates)
+	dispatcher := outbox.NewDispatcher(pool, mailer, logger, outbox.DefaultConfig())
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	dispatchDone := make(chan struct{})
+
+	go func() {
+		defer close(dispatchDone)
+		dispatcher.Run(dispatchCtx)
+	}()
+
+	// deferred before the server shutdown so it runs after it, once no request
+	// can write to the outbox anymore
+	defer func() {
+		stopDispatch()
+		<-dispatchDone
+
+		const timeout = 30 * time.Second
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := dispatcher.Drain(ctx); err != nil {
+			logger.Error("Failed to drain the outbox", zap.Error(err))
+		}
+	}()
+
+	// the schedulers only write to the outbox, so they are stopped before the
+	// dispatcher drains it
+	reminderConfig, err := reminder.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	scheduler := reminder.NewScheduler(pool, logger, reminderConfig)
+	scheduleCtx, stopSchedule := context.WithCancel(context.Background())
+	scheduleDone := make(chan struct{})
+
+	go func() {
+		defer close(scheduleDone)
+		scheduler.Run(scheduleCtx)
+	}()
+
+	defer func() {
+		stopSchedule()
+		<-scheduleDone
+	}()
+
+	digestConfig, err := digest.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	digests := digest.NewScheduler(pool, logger, digestConfig)
+	digestCtx, stopDigests := context.WithCancel(context.Background())
+	digestsDone := make(chan struct{})
+
+	go func() {
+		defer close(digestsDone)
+		digests.Run(digestCtx)
+	}()
+
+	defer func() {
+		stopDigests()
+		<-digestsDone
+	}()
 
 	srv := &http.Server{
 		Addr:         ":8080",
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"planner-go/internal/api/spec"
+	"planner-go/internal/pgstore"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultCategory is the category of activities created without one.
+const defaultCategory = "other"
+
+// activityParams maps the body of a request that creates or updates an
+// activity to its columns. The end of the activity is stored as an instant
+// whether it was sent as one or as a duration.
+func activityParams(tripID uuid.UUID, body spec.CreateActivityRequest) pgstore.CreateActivityParams {
+	params := pgstore.CreateActivityParams{
+		TripID:    tripID,
+		Title:     body.Title,
+		OccursAt:  pgtype.Timestamptz{Time: body.OccursAt, Valid: true},
+		TimeZone:  optionalText(body.TimeZone),
+		Location:  optionalText(body.Location),
+		Latitude:  optionalFloat(body.Latitude),
+		Longitude: optionalFloat(body.Longitude),
+		Category:  defaultCategory,
+	}
+
+	switch {
+	case body.EndsAt != nil:
+		params.EndsAt = pgtype.Timestamptz{Time: *body.EndsAt, Valid: true}
+	case body.DurationMinutes != nil:
+		params.EndsAt = pgtype.Timestamptz{Time: body.OccursAt.Add(time.Duration(*body.DurationMinutes) * time.Minute), Valid: true}
+	}
+	if body.Category != nil {
+		params.Category = *body.Category
+	}
+	if body.Notes != nil {
+		params.Notes = *body.Notes
+	}
+
+	return params
+}
+
+// newActivity returns the activity id would be once params are stored, to be
+// checked against the trip and its other activities beforehand.
+func newActivity(id uuid.UUID, params pgstore.CreateActivityParams) pgstore.Activity {
+	return pgstore.Activity{
+		ID:        id,
+		TripID:    params.TripID,
+		Title:     params.Title,
+		OccursAt:  params.OccursAt,
+		TimeZone:  params.TimeZone,
+		EndsAt:    params.EndsAt,
+		Location:  params.Location,
+		Latitude:  params.Latitude,
+		Longitude: params.Longitude,
+		Category:  params.Category,
+		Notes:     params.Notes,
+	}
+}
+
+// activityDetails writes an activity the way it is listed, with its times in
+// its time zone.
+func activityDetails(trip pgstore.Trip, activity pgstore.Activity) spec.GetTripActivitiesResponseInnerArray {
+	loc := activity.Zone(trip)
+	details := spec.GetTripActivitiesResponseInnerArray{
+		ID:        activity.ID.String(),
+		Title:     activity.Title,
+		OccursAt:  activity.OccursAt.Time.In(loc),
+		TimeZone:  nullableText(activity.TimeZone),
+		Location:  nullableText(activity.Location),
+		Latitude:  nullableFloat(activity.Latitude),
+		Longitude: nullableFloat(activity.Longitude),
+		Category:  activity.Category,
+		Notes:     activity.Notes,
+	}
+
+	if activity.EndsAt.Valid {
+		endsAt := activity.EndsAt.Time.In(loc)
+		minutes := int(endsAt.Sub(activity.OccursAt.Time) / time.Minute)
+		details.EndsAt = &endsAt
+		details.DurationMinutes = &minutes
+	}
+
+	return details
+}
+
+// optionalText and optionalFloat turn optional fields of a request into
+// nullable columns, and nullableText and nullableFloat do the opposite.
+func optionalText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *s, Valid: true}
+}
+
+func optionalFloat(f *float64) pgtype.Float8 {
+	if f == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *f, Valid: true}
+}
+
+func nullableText(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+func nullableFloat(f pgtype.Float8) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
@@ -2,12 +2,22 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
-	"net/mail"
 	"planner-go/internal/api/spec"
+	"planner-go/internal/exchange"
+	"planner-go/internal/i18n"
+	"planner-go/internal/ical"
+	"planner-go/internal/magiclink"
+	"planner-go/internal/mailer/inbound"
+	"planner-go/internal/money"
 	"planner-go/internal/pgstore"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,30 +28,70 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type store interface {
+	//user functions
+	CreateUser(context.Context, pgstore.CreateUserParams) (uuid.UUID, error)
+	GetUserByEmail(context.Context, string) (pgstore.User, error)
+	CreateSession(context.Context, pgstore.CreateSessionParams) error
+	GetSessionUser(context.Context, []byte) (pgstore.User, error)
+	DeleteSession(context.Context, []byte) error
 	//trip functions
 	GetTrip(context.Context, uuid.UUID) (pgstore.Trip, error)
-	CreateTrip(context.Context, *pgxpool.Pool, spec.CreateTripRequest) (uuid.UUID, error)
-	UpdateTrip(context.Context, pgstore.UpdateTripParams) error
-	InviteParticipantToTrip(context.Context, pgstore.InviteParticipantToTripParams) (uuid.UUID, error)
+	ListTripsByEmail(context.Context, pgstore.ListTripsByEmailParams) ([]pgstore.Trip, error)
+	CreateTrip(context.Context, *pgxpool.Pool, pgstore.User, spec.CreateTripRequest) (uuid.UUID, error)
+	UpdateTripChecked(context.Context, *pgxpool.Pool, pgstore.UpdateTripParams, func(pgstore.Trip, []pgstore.Activity) error) error
+	ConfirmTripAndNotify(context.Context, *pgxpool.Pool, uuid.UUID) (bool, error)
+	DeleteTripAndNotify(context.Context, *pgxpool.Pool, uuid.UUID) (pgstore.Trip, error)
+	InviteParticipantAndNotify(context.Context, *pgxpool.Pool, pgstore.InviteParticipantToTripParams) (uuid.UUID, error)
+	InviteParticipantsAndNotify(context.Context, *pgxpool.Pool, uuid.UUID, []pgstore.InviteParticipantsToTripParams) ([]string, error)
 	//participant functions
 	GetParticipant(context.Context, uuid.UUID) (pgstore.Participant, error)
+	ListDigestOptOuts(context.Context, uuid.UUID) ([]uuid.UUID, error)
+	OptOutOfDigests(context.Context, uuid.UUID) error
+	OptInToDigests(context.Context, uuid.UUID) error
 	GetParticipants(context.Context, uuid.UUID) ([]pgstore.Participant, error)
-	ConfirmParticipant(context.Context, uuid.UUID) error
+	ListTripParticipants(context.Context, pgstore.ListTripParticipantsParams) ([]pgstore.Participant, error)
+	GetTripParticipantByEmail(context.Context, pgstore.GetTripParticipantByEmailParams) (pgstore.Participant, error)
+	SetParticipantStatus(context.Context, pgstore.SetParticipantStatusParams) (int64, error)
+	ConfirmTripWithToken(context.Context, *pgxpool.Pool, uuid.UUID) (uuid.UUID, bool, error)
+	ConfirmParticipantWithToken(context.Context, *pgxpool.Pool, uuid.UUID) (uuid.UUID, error)
+	RequestAccountClaim(context.Context, *pgxpool.Pool, pgstore.User, pgstore.CreateUserParams) error
+	ClaimAccountWithToken(context.Context, *pgxpool.Pool, uuid.UUID) (uuid.UUID, error)
+	UpdateParticipantRole(context.Context, pgstore.UpdateParticipantRoleParams) error
+	ReinviteParticipantAndNotify(context.Context, *pgxpool.Pool, uuid.UUID, pgstore.ReinviteParticipantParams) (bool, error)
+	RemoveParticipantAndNotify(context.Context, *pgxpool.Pool, pgstore.Trip, pgstore.RemoveParticipantParams) (pgstore.Participant, error)
+	TransferTripOwnership(context.Context, *pgxpool.Pool, pgstore.Trip, pgstore.Participant, pgstore.User) error
 	//activities functions
-	GetTripActivities(context.Context, uuid.UUID) ([]pgstore.Activity, error)
-	CreateActivity(context.Context, pgstore.CreateActivityParams) (uuid.UUID, error)
+	ListTripActivities(context.Context, pgstore.ListTripActivitiesParams) ([]pgstore.Activity, error)
+	CreateActivityChecked(context.Context, *pgxpool.Pool, pgstore.CreateActivityParams, func(pgstore.Trip, []pgstore.Activity) error) (uuid.UUID, error)
+	UpdateActivityChecked(context.Context, *pgxpool.Pool, pgstore.UpdateActivityParams, func(pgstore.Trip, []pgstore.Activity) error) (int64, error)
+	DeleteActivityAndNotify(context.Context, *pgxpool.Pool, pgstore.DeleteActivityParams) (pgstore.Activity, error)
 	//trips functions
-	GetTripLinks(context.Context, uuid.UUID) ([]pgstore.Link, error)
+	ListTripLinks(context.Context, pgstore.ListTripLinksParams) ([]pgstore.Link, error)
 	CreateTripLink(context.Context, pgstore.CreateTripLinkParams) (uuid.UUID, error)
-}
-
-type mailer interface {
-	SendConfirmEmailToTripOwner(uuid.UUID) error
-	SendConfirmEmailToParticipants(uuid.UUID) error
-	SendConfirmEmailToInvitedParticipant(tripId, participantId uuid.UUID) error
+	UpdateTripLink(context.Context, pgstore.UpdateTripLinkParams) (int64, error)
+	DeleteTripLinkAndNotify(context.Context, *pgxpool.Pool, pgstore.DeleteTripLinkParams) (pgstore.Link, error)
+	//expenses functions
+	GetTripActivity(context.Context, pgstore.GetTripActivityParams) (pgstore.Activity, error)
+	ListTripExpenses(context.Context, pgstore.ListTripExpensesParams) ([]pgstore.Expense, error)
+	GetExpenseShares(context.Context, []uuid.UUID) ([]pgstore.ExpenseShare, error)
+	CreateExpense(context.Context, *pgxpool.Pool, pgstore.InsertExpenseParams, []pgstore.InsertExpenseSharesParams) (uuid.UUID, error)
+	DeleteExpense(context.Context, pgstore.DeleteExpenseParams) (int64, error)
+	GetTripPaidTotals(context.Context, uuid.UUID) ([]pgstore.GetTripPaidTotalsRow, error)
+	GetTripOwedTotals(context.Context, uuid.UUID) ([]pgstore.GetTripOwedTotalsRow, error)
+	//budget functions
+	GetBudget(context.Context, uuid.UUID) (pgstore.Budget, error)
+	GetBudgetCategories(context.Context, uuid.UUID) ([]pgstore.BudgetCategory, error)
+	GetTripSpending(context.Context, uuid.UUID) ([]pgstore.GetTripSpendingRow, error)
+	SetBudget(context.Context, *pgxpool.Pool, pgstore.UpsertBudgetParams, []pgstore.InsertBudgetCategoriesParams) error
+	//calendar functions
+	GetTripActivities(context.Context, uuid.UUID) ([]pgstore.Activity, error)
+	UpsertCalendarSubscription(context.Context, pgstore.UpsertCalendarSubscriptionParams) error
+	DeleteCalendarSubscription(context.Context, pgstore.DeleteCalendarSubscriptionParams) (int64, error)
+	GetCalendarSubscriptionUser(context.Context, pgstore.GetCalendarSubscriptionUserParams) (pgstore.User, error)
 }
 
 type API struct {
@@ -49,424 +99,2308 @@ type API struct {
 	logger    *zap.Logger
 	validator *validator.Validate
 	pool      *pgxpool.Pool
-	mailer    mailer
+	signer    magiclink.Signer
+	baseURL   string
+	// rates converts expenses to the currency of the trip budget.
+	rates exchange.Provider
+	// inboundSecret guards the endpoint fed by the mail server. Empty disables
+	// it.
+	inboundSecret string
 }
 
-func NewApi(pool *pgxpool.Pool, logger *zap.Logger, mailer mailer) API {
+func NewApi(pool *pgxpool.Pool, logger *zap.Logger, signer magiclink.Signer, baseURL, inboundSecret string) API {
 	validator := validator.New(validator.WithRequiredStructEnabled())
-	return API{pgstore.New(pool), logger, validator, pool, mailer}
+	validator.RegisterTagNameFunc(jsonFieldName)
+	queries := pgstore.New(pool)
+	return API{queries, logger, validator, pool, signer, baseURL, queries, inboundSecret}
 }
 
-// Confirms a participant on a trip.
-// (PATCH /participants/{participantId}/confirm)
-func (api API) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
-	id, err := uuid.Parse(participantID)
+// Confirm a participant from the link sent by e-mail.
+// (GET /confirmations/participants/{token})
+func (api API) GetConfirmationsParticipantsToken(w http.ResponseWriter, r *http.Request, token string) *spec.Response {
+	tokenId, err := api.signer.Verify(magiclink.PurposeParticipant, token, time.Now())
 	if err != nil {
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Invalid UUID"})
+		return api.problem(w, r, problemInvalidRequest, "Invalid or expired token")
 	}
 
-	participant, err := api.store.GetParticipant(r.Context(), id)
-	if err != nil {
+	if _, err := api.store.ConfirmParticipantWithToken(r.Context(), api.pool, tokenId); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Participant not found"})
+			return api.problem(w, r, problemConflict, "Token was already used or has expired")
 		}
-		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Something went wrong"})
+		if errors.Is(err, pgstore.ErrParticipantRemoved) {
+			return api.problem(w, r, problemConflict, "Participant was removed from the trip")
+		}
+		if errors.Is(err, pgstore.ErrInvitationExpired) {
+			return api.problem(w, r, problemConflict, "The invitation has expired")
+		}
+		api.logger.Error("Failed to confirm participant with token", zap.Error(err), zap.String("token_id", tokenId.String()))
+		return api.internalError(w, r)
 	}
 
-	if participant.IsConfirmed {
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Participant already confirmed"})
+	return spec.GetConfirmationsParticipantsTokenJSON204Response(nil)
+}
+
+// Claim an account from the link sent by e-mail.
+// (GET /confirmations/accounts/{token})
+func (api API) GetConfirmationsAccountsToken(w http.ResponseWriter, r *http.Request, token string) *spec.Response {
+	tokenId, err := api.signer.Verify(magiclink.PurposeAccount, token, time.Now())
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid or expired token")
 	}
 
-	if err := api.store.ConfirmParticipant(r.Context(), id); err != nil {
-		api.logger.Error("Failed to confirm participant", zap.Error(err), zap.String("participant_id", participantID))
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Something went wrong"})
+	if _, err := api.store.ClaimAccountWithToken(r.Context(), api.pool, tokenId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemConflict, "Token was already used or has expired")
+		}
+		if errors.Is(err, pgstore.ErrAccountClaimed) {
+			return api.problem(w, r, problemConflict, "Email already registered")
+		}
+		api.logger.Error("Failed to claim account with token", zap.Error(err), zap.String("token_id", tokenId.String()))
+		return api.internalError(w, r)
 	}
 
-	return spec.PatchParticipantsParticipantIDConfirmJSON204Response(nil)
+	return spec.GetConfirmationsAccountsTokenJSON204Response(nil)
 }
 
-// Create a new trip
-// (POST /trips)
-func (api API) PostTrips(w http.ResponseWriter, r *http.Request) *spec.Response {
-	var body spec.CreateTripRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "Invalid JSON Body"})
-	}
-
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "Invalid input field" + err.Error()})
+// Confirm a trip from the link sent by e-mail.
+// (GET /confirmations/trips/{token})
+func (api API) GetConfirmationsTripsToken(w http.ResponseWriter, r *http.Request, token string) *spec.Response {
+	tokenId, err := api.signer.Verify(magiclink.PurposeTrip, token, time.Now())
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid or expired token")
 	}
 
-	tripId, err := api.store.CreateTrip(r.Context(), api.pool, body)
+	_, confirmed, err := api.store.ConfirmTripWithToken(r.Context(), api.pool, tokenId)
 	if err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "Something went wrong"})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemConflict, "Token was already used or has expired")
+		}
+		api.logger.Error("Failed to confirm trip with token", zap.Error(err), zap.String("token_id", tokenId.String()))
+		return api.internalError(w, r)
 	}
 
-	go func() {
-		if err := api.mailer.SendConfirmEmailToTripOwner(tripId); err != nil {
-			api.logger.Error("Failed to send email on PostTrips",
-				zap.Error(err),
-				zap.String("trip_id", tripId.String()))
-		}
-	}()
+	if !confirmed {
+		return api.problem(w, r, problemConflict, "Trip is already confirmed")
+	}
 
-	return spec.PostTripsJSON201Response(spec.CreateTripResponse{TripID: tripId.String()})
+	return spec.GetConfirmationsTripsTokenJSON204Response(nil)
 }
 
-// Get a trip details.
-// (GET /trips/{tripId})
-func (api API) GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
+// maxInboundMessageSize bounds the e-mails accepted by PostInboundItip.
+const maxInboundMessageSize = 1 << 20
+
+// Process an iTIP reply to a trip invitation.
+// (POST /inbound/itip)
+func (api API) PostInboundItip(w http.ResponseWriter, r *http.Request, params spec.PostInboundItipParams) *spec.Response {
+	if api.inboundSecret == "" {
+		return api.problem(w, r, problemNotFound, "Route not found")
+	}
+
+	if params.XPlannerInboundSecret == nil ||
+		subtle.ConstantTimeCompare([]byte(*params.XPlannerInboundSecret), []byte(api.inboundSecret)) != 1 {
+		return api.problem(w, r, problemUnauthorized, "A valid inbound secret is required")
+	}
+
+	from, reply, err := inbound.CalendarReply(http.MaxBytesReader(w, r.Body, maxInboundMessageSize))
 	if err != nil {
-		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Invalid UUId"})
+		api.logger.Info("Rejected inbound message", zap.Error(err))
+		return api.problem(w, r, problemInvalidRequest, "The message is not a valid iTIP reply")
 	}
 
-	trip, err := api.store.GetTrip(r.Context(), id)
+	// the mail server vouches for the sender, who may only answer for themselves
+	if !strings.EqualFold(from, reply.Attendee) {
+		return api.problem(w, r, problemForbidden, "The sender of the reply is not its attendee")
+	}
+
+	kind, tripId, ok := ical.ParseUID(reply.UID)
+	if !ok || kind != "trip" {
+		return api.problem(w, r, problemNotFound, "Trip not found")
+	}
 
+	participant, err := api.store.GetTripParticipantByEmail(r.Context(), pgstore.GetTripParticipantByEmailParams{
+		TripID: tripId,
+		Email:  reply.Attendee,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Trip not found"})
+			return api.problem(w, r, problemNotFound, "Participant not found")
 		}
-		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("trip_id", tripId.String()))
+		return api.internalError(w, r)
 	}
 
-	return spec.GetTripsTripIDJSON200Response(spec.GetTripDetailsResponse{Trip: spec.GetTripDetailsResponseTripObj{
-		ID:          tripID,
-		Destination: trip.Destination,
-		StartsAt:    trip.StartsAt.Time,
-		EndsAt:      trip.EndsAt.Time,
-		IsConfirmed: trip.IsConfirmed,
-	}})
+	status, ok := partStatus(reply.PartStat)
+	if !ok {
+		// delegated answers leave the participant as they are
+		return spec.PostInboundItipJSON204Response(nil)
+	}
+
+	if participant.Status == pgstore.RSVPExpired {
+		return api.problem(w, r, problemConflict, "The invitation has expired")
+	}
+	if participant.Status != status && !canTransition(participant.Status, status) {
+		return api.problem(w, r, problemConflict, "Participant was removed from the trip")
+	}
+
+	updated, err := api.store.SetParticipantStatus(r.Context(), pgstore.SetParticipantStatusParams{
+		ID:     participant.ID,
+		Status: status,
+	})
+	if err != nil {
+		api.logger.Error("Failed to set participant status",
+			zap.Error(err),
+			zap.String("participant_id", participant.ID.String()),
+		)
+		return api.internalError(w, r)
+	}
+
+	if updated == 0 {
+		return api.problem(w, r, problemConflict, "Participant was removed from the trip")
+	}
+
+	return spec.PostInboundItipJSON204Response(nil)
 }
 
-// Update a trip.
-// (PUT /trips/{tripId})
-func (api API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	var body spec.PutTripsTripIDJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "Invalid JSON Body"})
+// Confirms a participant on a trip.
+// (PATCH /participants/{participantId}/confirm)
+func (api API) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	// the body is optional, and only carries the name to be shown with
+	var body spec.PatchParticipantsParticipantIDConfirmJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
 	}
 
 	if err := api.validator.Struct(body); err != nil {
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Invalid input field" + err.Error()})
+		return api.validationFailed(w, r, err)
 	}
 
-	id, err := uuid.Parse(tripID)
+	id, err := uuid.Parse(participantID)
 	if err != nil {
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Invalid UUID"})
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
 	}
 
-	trip, err := api.store.GetTrip(r.Context(), id)
+	participant, err := api.store.GetParticipant(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Trip not found"})
+			return api.problem(w, r, problemNotFound, "Participant not found")
 		}
-		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
 	}
 
-	if err := api.store.UpdateTrip(r.Context(), pgstore.UpdateTripParams{
-		Destination: body.Destination,
-		StartsAt:    pgtype.Timestamp{Time: body.StartsAt, Valid: true},
-		EndsAt:      pgtype.Timestamp{Time: body.EndsAt, Valid: true},
-		IsConfirmed: trip.IsConfirmed,
-		ID:          id,
-	}); err != nil {
-		api.logger.Error("Failed to update trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Something went wrong"})
+	if !strings.EqualFold(participant.Email, user.Email) {
+		return api.problem(w, r, problemForbidden, "Participant belongs to another user")
 	}
 
-	return spec.PutTripsTripIDJSON204Response(nil)
+	if participant.Accepted() {
+		return api.problem(w, r, problemConflict, "Participant already confirmed")
+	}
+
+	if resp := api.respondInvitation(w, r, participant, pgstore.RSVPAccepted, body.Name); resp != nil {
+		return resp
+	}
+
+	return spec.PatchParticipantsParticipantIDConfirmJSON204Response(nil)
 }
 
-// Get a trip activities.
-// (GET /trips/{tripId}/activities)
-func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Invalid UUID"})
+// Answer the invitation of a participant to a trip.
+// (PATCH /participants/{participantId}/rsvp)
+func (api API) PatchParticipantsParticipantIDRsvp(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
 	}
 
-	activities, err := api.store.GetTripActivities(r.Context(), id)
+	var body spec.PatchParticipantsParticipantIDRsvpJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
 
-	if !(len(activities) > 0) {
-		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "No activities found"})
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
 	}
 
+	participant, err := api.store.GetParticipant(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Trip not found"})
+			return api.problem(w, r, problemNotFound, "Participant not found")
 		}
-		api.logger.Error("Failed to get trip participants", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
+	}
+
+	if !strings.EqualFold(participant.Email, user.Email) {
+		return api.problem(w, r, problemForbidden, "Participant belongs to another user")
 	}
 
-	var response spec.GetTripActivitiesResponse
+	if resp := api.respondInvitation(w, r, participant, body.Status, body.Name); resp != nil {
+		return resp
+	}
 
-	//group activities by date
-	groupedAct := make(map[string][]pgstore.Activity)
+	return spec.PatchParticipantsParticipantIDRsvpJSON204Response(nil)
+}
 
-	for _, activity := range activities {
-		date := activity.OccursAt.Time.Format(time.DateOnly)
-		groupedAct[date] = append(groupedAct[date], activity)
+// respondInvitation moves participant to status, answering again with the
+// status they already have to change their name. It returns nil once the
+// answer is stored, and the problem to respond with otherwise.
+func (api API) respondInvitation(w http.ResponseWriter, r *http.Request, participant pgstore.Participant, status string, name *string) *spec.Response {
+	// the owner takes part in the trip for as long as they own it
+	if role(participant.Role) == roleOwner && status != pgstore.RSVPAccepted {
+		return api.problem(w, r, problemConflict, "The trip owner cannot decline the trip")
 	}
 
-	//format activities for response
-	for dtString, actsOnDate := range groupedAct {
-		var inActs []spec.GetTripActivitiesResponseInnerArray
+	if participant.Status == pgstore.RSVPExpired {
+		return api.problem(w, r, problemConflict, "The invitation has expired")
+	}
+	if participant.Status != status && !canTransition(participant.Status, status) {
+		return api.problem(w, r, problemConflict, "Participant was removed from the trip")
+	}
 
-		for _, act := range actsOnDate {
-			inActs = append(inActs, spec.GetTripActivitiesResponseInnerArray{
-				ID:       act.ID.String(),
-				Title:    act.Title,
-				OccursAt: act.OccursAt.Time,
-			})
-		}
+	params := pgstore.SetParticipantStatusParams{ID: participant.ID, Status: status}
+	if name != nil {
+		params.Name = pgtype.Text{String: *name, Valid: true}
+	}
 
-		date, _ := time.Parse(time.DateOnly, dtString)
-		response.Activities = append(response.Activities, spec.GetTripActivitiesResponseOuterArray{
-			Date:       date,
-			Activities: inActs,
-		})
+	updated, err := api.store.SetParticipantStatus(r.Context(), params)
+	if err != nil {
+		api.logger.Error("Failed to set participant status", zap.Error(err), zap.String("participant_id", participant.ID.String()))
+		return api.internalError(w, r)
 	}
 
-	return spec.GetTripsTripIDActivitiesJSON200Response(response)
+	// the owner removed them or the invitation expired in the meantime
+	if updated == 0 {
+		return api.problem(w, r, problemConflict, "Participant was removed from the trip")
+	}
+
+	return nil
 }
 
-// Create a trip activity.
-// (POST /trips/{tripId}/activities)
-func (api API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	var body spec.PostTripsTripIDActivitiesJSONRequestBody
+// Change the role of a participant on a trip.
+// (PATCH /participants/{participantId}/role)
+func (api API) PatchParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
 
+	var body spec.PatchParticipantsParticipantIDRoleJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Invalid JSON Body"})
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
 	}
 
 	if err := api.validator.Struct(body); err != nil {
-		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Invalid input field" + err.Error()})
+		return api.validationFailed(w, r, err)
 	}
 
-	id, err := uuid.Parse(tripID)
+	id, err := uuid.Parse(participantID)
 	if err != nil {
-		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Invalid UUID"})
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
 	}
 
-	activityId, err := api.store.CreateActivity(r.Context(), pgstore.CreateActivityParams{
-		TripID:   id,
-		Title:    body.Title,
-		OccursAt: pgtype.Timestamp{Time: body.OccursAt, Valid: true},
-	})
-
+	participant, err := api.store.GetParticipant(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Trip not found"})
+			return api.problem(w, r, problemNotFound, "Participant not found")
 		}
-		api.logger.Error("Failed to create activity", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Something went wrong"})
-	}
-
-	return spec.PostTripsTripIDActivitiesJSON201Response(spec.CreateActivityResponse{ActivityID: activityId.String()})
-}
-
-// Confirm a trip and send e-mail invitations.
-// (GET /trips/{tripId}/confirm)
-func (api API) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Invalid UUID"})
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
 	}
 
-	trip, err := api.store.GetTrip(r.Context(), id)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Trip not found"})
+	if _, err := api.authorize(r.Context(), user, participant.TripID, actionManage); err != nil {
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can change roles")
 		}
-		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
 	}
 
-	if trip.IsConfirmed {
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Trip is already confirmed"})
+	if role(participant.Role) == roleOwner {
+		return api.problem(w, r, problemConflict, "The owner role cannot be changed")
 	}
 
-	if err := api.store.UpdateTrip(r.Context(), pgstore.UpdateTripParams{
-		ID:          id,
-		Destination: trip.Destination,
-		EndsAt:      trip.EndsAt,
-		StartsAt:    trip.StartsAt,
-		IsConfirmed: true,
+	if err := api.store.UpdateParticipantRole(r.Context(), pgstore.UpdateParticipantRoleParams{
+		Role: body.Role,
+		ID:   id,
 	}); err != nil {
-		api.logger.Error("Failed to update trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to update participant role", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
 	}
 
-	go func() {
-		if err := api.mailer.SendConfirmEmailToParticipants(id); err != nil {
-			api.logger.Error("Failed to send email on GetTripsTripIDConfirm",
-				zap.Error(err),
-				zap.String("trip_id", tripID))
-		}
-	}()
+	return spec.PatchParticipantsParticipantIDRoleJSON204Response(nil)
+}
 
-	return spec.GetTripsTripIDConfirmJSON204Response(nil)
+// Tell whether a participant receives the digest e-mails of their trip.
+// (GET /participants/{participantId}/digests)
+func (api API) GetParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+	participant, resp := api.digestParticipant(w, r, participantID)
+	if resp != nil {
+		return resp
+	}
+
+	optOuts, err := api.store.ListDigestOptOuts(r.Context(), participant.TripID)
+	if err != nil {
+		api.logger.Error("Failed to list digest opt-outs", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
+	}
+
+	return spec.GetParticipantsParticipantIDDigestsJSON200Response(spec.DigestSubscriptionResponse{
+		Enabled: !slices.Contains(optOuts, participant.ID),
+	})
 }
 
-// Invite someone to the trip.
-// (POST /trips/{tripId}/invites)
-func (api API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	var body spec.PostTripsTripIDInvitesJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Invalid JSON Body"})
+// Send a participant the digest e-mails of their trip again.
+// (PUT /participants/{participantId}/digests)
+func (api API) PutParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+	participant, resp := api.digestParticipant(w, r, participantID)
+	if resp != nil {
+		return resp
 	}
 
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Invalid input field" + err.Error()})
+	if err := api.store.OptInToDigests(r.Context(), participant.ID); err != nil {
+		api.logger.Error("Failed to opt in to digests", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
 	}
 
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Invalid UUID"})
+	return spec.PutParticipantsParticipantIDDigestsJSON204Response(nil)
+}
+
+// Stop sending a participant the digest e-mails of their trip.
+// (DELETE /participants/{participantId}/digests)
+func (api API) DeleteParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+	participant, resp := api.digestParticipant(w, r, participantID)
+	if resp != nil {
+		return resp
 	}
 
-	participants, err := api.store.GetParticipants(r.Context(), id)
-	if err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Failed to get participants"})
+	if err := api.store.OptOutOfDigests(r.Context(), participant.ID); err != nil {
+		api.logger.Error("Failed to opt out of digests", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
 	}
 
-	for _, participant := range participants {
-		if participant.Email == string(body.Email) {
-			return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Participant has already joined the trip"})
-		}
+	return spec.DeleteParticipantsParticipantIDDigestsJSON204Response(nil)
+}
+
+// digestParticipant returns the participant whose digests are read or changed,
+// which only they can do. It returns the problem to respond with otherwise.
+func (api API) digestParticipant(w http.ResponseWriter, r *http.Request, participantID string) (pgstore.Participant, *spec.Response) {
+	user, ok := currentUser(r)
+	if !ok {
+		return pgstore.Participant{}, api.problem(w, r, problemUnauthorized, "A valid session token is required")
 	}
 
-	participantId, err := api.store.InviteParticipantToTrip(r.Context(), pgstore.InviteParticipantToTripParams{
-		TripID: id,
-		Email:  string(body.Email),
-	})
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return pgstore.Participant{}, api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
 
+	participant, err := api.store.GetParticipant(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Trip not found"})
+			return pgstore.Participant{}, api.problem(w, r, problemNotFound, "Participant not found")
 		}
-		api.logger.Error("Failed to invite participant for the trip",
-			zap.Error(err),
-			zap.String("trip_id", tripID),
-			zap.String("participant_id", participantId.String()),
-			zap.String("participant_email", string(body.Email)),
-		)
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return pgstore.Participant{}, api.internalError(w, r)
 	}
 
-	go func() {
-		if err := api.mailer.SendConfirmEmailToInvitedParticipant(id, participantId); err != nil {
-			api.logger.Error("Failed to send email on PostTripsInvites",
-				zap.Error(err),
-				zap.String("trip_id", tripID))
-		}
-	}()
+	if !strings.EqualFold(participant.Email, user.Email) {
+		return pgstore.Participant{}, api.problem(w, r, problemForbidden, "Participant belongs to another user")
+	}
 
-	return spec.PostTripsTripIDInvitesJSON201Response(nil)
+	return participant, nil
 }
 
-// Get a trip links.
-// (GET /trips/{tripId}/links)
-func (api API) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
+// List the trips the user owns or takes part in.
+// (GET /trips)
+func (api API) GetTrips(w http.ResponseWriter, r *http.Request, params spec.GetTripsParams) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	pg, err := newPage(params.Limit, params.Cursor)
 	if err != nil {
-		return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "Invalid JSON Body"})
+		return api.problem(w, r, problemInvalidRequest, "Invalid limit or cursor")
 	}
 
-	links, err := api.store.GetTripLinks(r.Context(), id)
+	filter := pgstore.ListTripsByEmailParams{
+		Email:         user.Email,
+		AfterStartsAt: pg.afterTimestamptz(),
+		AfterID:       pg.afterID(),
+		RowLimit:      pg.rowLimit(),
+	}
+	if params.Destination != nil {
+		filter.Destination = pgtype.Text{String: *params.Destination, Valid: true}
+	}
+	// dates are compared in the time zone of each trip, in the query
+	if params.From != nil {
+		filter.EndsOnOrAfter = pgtype.Date{Time: params.From.Time, Valid: true}
+	}
+	if params.To != nil {
+		filter.StartsOnOrBefore = pgtype.Date{Time: params.To.Time, Valid: true}
+	}
+	if params.IsConfirmed != nil {
+		filter.IsConfirmed = pgtype.Bool{Bool: *params.IsConfirmed, Valid: true}
+	}
+
+	trips, err := api.store.ListTripsByEmail(r.Context(), filter)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "Trip not found"})
-		}
-		api.logger.Error("Failed to get trip links", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to list trips", zap.Error(err), zap.String("user_id", user.ID.String()))
+		return api.internalError(w, r)
 	}
 
-	var response spec.GetLinksResponse
-	// format items for response
-	for _, link := range links {
-		response.Links = append(response.Links, spec.GetLinksResponseArray{
-			ID:    tripID,
-			Title: link.Title,
-			URL:   link.Url,
-		})
+	trips, next := paginate(pg, trips, func(trip pgstore.Trip) cursor {
+		return cursor{At: trip.StartsAt.Time, ID: trip.ID}
+	})
+
+	response := spec.GetTripsResponse{NextCursor: next}
+	response.Trips = make([]spec.GetTripDetailsResponseTripObj, len(trips))
+
+	for i, trip := range trips {
+		response.Trips[i] = tripDetails(trip)
 	}
 
-	return spec.GetTripsTripIDLinksJSON200Response(response)
+	return spec.GetTripsJSON200Response(response)
 }
 
-// Create a trip link.
-// (POST /trips/{tripId}/links)
-func (api API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	var body spec.PostTripsTripIDLinksJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Invalid JSON Body"})
+// Create a new trip
+// (POST /trips)
+func (api API) PostTrips(w http.ResponseWriter, r *http.Request) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
 	}
 
-	if err := api.validator.Struct(body); err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Invalid input field" + err.Error()})
+	var body spec.CreateTripRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
 	}
 
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Invalid UUID"})
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
 	}
 
-	linkId, err := api.store.CreateTripLink(r.Context(), pgstore.CreateTripLinkParams{
-		TripID: id,
-		Title:  body.Title,
-		Url:    body.URL,
-	})
+	tripId, err := api.store.CreateTrip(r.Context(), api.pool, user, body)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Trip not found"})
-		}
-		api.logger.Error("Failed to insert link in trip", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Something went wrong"})
+		api.logger.Error("Failed to create trip", zap.Error(err), zap.String("user_id", user.ID.String()))
+		return api.internalError(w, r)
 	}
 
-	return spec.PostTripsTripIDLinksJSON201Response(spec.CreateLinkResponse{LinkID: linkId.String()})
+	return spec.PostTripsJSON201Response(spec.CreateTripResponse{TripID: tripId.String()})
 }
 
-// Get a trip participants.
-// (GET /trips/{tripId}/participants)
-func (api API) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+// Get a trip details.
+// (GET /trips/{tripId})
+func (api API) GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "Invalid UUID"})
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
 	}
 
-	participants, err := api.store.GetParticipants(r.Context(), id)
+	trip, err := api.authorize(r.Context(), user, id, actionRead)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "Trip not found"})
+			return api.problem(w, r, problemNotFound, "Trip not found")
 		}
-		api.logger.Error("Failed to get trip participants", zap.Error(err), zap.String("trip_id", tripID))
-		return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "Something went wrong"})
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
 	}
 
-	var response spec.GetTripParticipantsResponse
-	response.Participants = make([]spec.GetTripParticipantsResponseArray, len(participants))
+	return spec.GetTripsTripIDJSON200Response(spec.GetTripDetailsResponse{Trip: tripDetails(trip)})
+}
 
-	for i, participant := range participants {
-		var name string
-		formattedEmail, err := mail.ParseAddress(participant.Email)
-		if err == nil {
-			addr := formattedEmail.Address
-			name = addr[:strings.Index(addr, "@")]
-		}
-		response.Participants[i] = spec.GetTripParticipantsResponseArray{
-			ID:          participant.ID.String(),
-			Email:       types.Email(participant.Email),
-			IsConfirmed: participant.IsConfirmed,
-			Name:        &name,
-		}
+// tripDetails writes the dates of a trip in its time zone.
+func tripDetails(trip pgstore.Trip) spec.GetTripDetailsResponseTripObj {
+	loc := trip.Zone()
+	return spec.GetTripDetailsResponseTripObj{
+		ID:          trip.ID.String(),
+		Destination: trip.Destination,
+		StartsAt:    trip.StartsAt.Time.In(loc),
+		EndsAt:      trip.EndsAt.Time.In(loc),
+		TimeZone:    trip.TimeZone,
+		IsConfirmed: trip.IsConfirmed,
 	}
+}
 
-	return spec.GetTripsTripIDParticipantsJSON200Response(response)
+// Update a trip.
+// (PUT /trips/{tripId})
+func (api API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PutTripsTripIDJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionManage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can update it")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	params := pgstore.UpdateTripParams{
+		Destination: body.Destination,
+		StartsAt:    pgtype.Timestamptz{Time: body.StartsAt, Valid: true},
+		EndsAt:      pgtype.Timestamptz{Time: body.EndsAt, Valid: true},
+		TimeZone:    trip.TimeZone,
+		ID:          id,
+	}
+	if body.TimeZone != nil {
+		params.TimeZone = *body.TimeZone
+	}
+
+	var orphans []pgstore.Activity
+	if err := api.store.UpdateTripChecked(r.Context(), api.pool, params, checkTripDates(params, &orphans)); err != nil {
+		if errors.Is(err, errActivitiesOrphaned) {
+			return api.problem(w, r, problemConflict, "Activities left outside the trip by the new dates: %d", len(orphans))
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		api.logger.Error("Failed to update trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.PutTripsTripIDJSON204Response(nil)
+}
+
+// Delete a trip and everything attached to it.
+// (DELETE /trips/{tripId})
+func (api API) DeleteTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionManage); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can delete it")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	if _, err := api.store.DeleteTripAndNotify(r.Context(), api.pool, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		api.logger.Error("Failed to delete trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.DeleteTripsTripIDJSON204Response(nil)
+}
+
+// Export a trip and its activities as an iCalendar file.
+// (GET /trips/{tripId}/calendar.ics)
+func (api API) GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDCalendarIcsParams) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	locale := i18n.FromContext(r.Context())
+	user, ok := currentUser(r)
+
+	// calendar apps cannot send a session token, so they use the one of the
+	// subscription instead
+	if params.Token != nil {
+		user, err = api.store.GetCalendarSubscriptionUser(r.Context(), pgstore.GetCalendarSubscriptionUserParams{
+			TripID:    id,
+			TokenHash: hashToken(*params.Token),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return api.problem(w, r, problemUnauthorized, "Invalid calendar subscription token")
+			}
+			api.logger.Error("Failed to get calendar subscription", zap.Error(err), zap.String("trip_id", tripID))
+			return api.internalError(w, r)
+		}
+		ok = true
+
+		if r.Header.Get("Accept-Language") == "" {
+			locale = i18n.Parse(user.Locale)
+		}
+	}
+
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionRead)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	activities, err := api.store.GetTripActivities(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip activities", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, trip.ID))
+	w.Header().Set("Content-Language", string(locale))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(tripCalendar(trip, activities, locale, time.Now()).Marshal()); err != nil {
+		api.logger.Error("Failed to write calendar", zap.Error(err), zap.String("trip_id", tripID))
+	}
+	return nil
+}
+
+// Create the calendar subscription of the caller for a trip, replacing any previous one.
+// (POST /trips/{tripId}/calendar/subscription)
+func (api API) PostTripsTripIDCalendarSubscription(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionRead); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		api.logger.Error("Failed to generate calendar token", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	if err := api.store.UpsertCalendarSubscription(r.Context(), pgstore.UpsertCalendarSubscriptionParams{
+		TripID:    id,
+		UserID:    user.ID,
+		TokenHash: hash,
+	}); err != nil {
+		api.logger.Error("Failed to create calendar subscription", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.PostTripsTripIDCalendarSubscriptionJSON201Response(spec.CalendarSubscriptionResponse{
+		URL: fmt.Sprintf("%s/trips/%s/calendar.ics?token=%s", api.baseURL, id, token),
+	})
+}
+
+// Revoke the calendar subscription of the caller for a trip.
+// (DELETE /trips/{tripId}/calendar/subscription)
+func (api API) DeleteTripsTripIDCalendarSubscription(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	rows, err := api.store.DeleteCalendarSubscription(r.Context(), pgstore.DeleteCalendarSubscriptionParams{
+		TripID: id,
+		UserID: user.ID,
+	})
+	if err != nil {
+		api.logger.Error("Failed to delete calendar subscription", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	if rows == 0 {
+		return api.problem(w, r, problemNotFound, "Calendar subscription not found")
+	}
+
+	return spec.DeleteTripsTripIDCalendarSubscriptionJSON204Response(nil)
+}
+
+// Get a trip activities.
+// (GET /trips/{tripId}/activities)
+func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDActivitiesParams) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	pg, err := newPage(params.Limit, params.Cursor)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid limit or cursor")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionRead)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	tripLoc := trip.Zone()
+	filter := pgstore.ListTripActivitiesParams{
+		TripID:        id,
+		TripTimeZone:  trip.TimeZone,
+		AfterOccursAt: pg.afterTimestamptz(),
+		AfterID:       pg.afterID(),
+		RowLimit:      pg.rowLimit(),
+	}
+
+	// the days of the trip to fill with empty ones, narrowed by the filters
+	first := localDay(trip.StartsAt.Time.In(tripLoc), tripLoc)
+	last := localDay(trip.EndsAt.Time.In(tripLoc), tripLoc)
+
+	if params.From != nil {
+		filter.FromDay = pgtype.Date{Time: params.From.Time, Valid: true}
+		if from := localDay(params.From.Time, tripLoc); from.After(first) {
+			first = from
+		}
+	}
+	if params.To != nil {
+		filter.ToDay = pgtype.Date{Time: params.To.Time, Valid: true}
+		if to := localDay(params.To.Time, tripLoc); to.Before(last) {
+			last = to
+		}
+	}
+	if params.Upcoming != nil && *params.Upcoming {
+		now := time.Now()
+		filter.EndingAfter = pgtype.Timestamptz{Time: now, Valid: true}
+		if today := localDay(now.In(tripLoc), tripLoc); today.After(first) {
+			first = today
+		}
+	}
+
+	activities, err := api.store.ListTripActivities(r.Context(), filter)
+	if err != nil {
+		api.logger.Error("Failed to get trip activities", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	activities, next := paginate(pg, activities, func(activity pgstore.Activity) cursor {
+		return cursor{At: activity.OccursAt.Time, ID: activity.ID}
+	})
+
+	response := spec.GetTripActivitiesResponse{
+		Activities: groupByDay(trip, activities),
+		NextCursor: next,
+	}
+
+	// a day may continue on the next page, so pages only fill the days between
+	// the one the previous page ended on and the one they end on
+	if params.IncludeEmptyDays != nil && *params.IncludeEmptyDays {
+		if pg.after != nil {
+			if day := localDay(pg.after.At.In(tripLoc), tripLoc).AddDate(0, 0, 1); day.After(first) {
+				first = day
+			}
+		}
+		if n := len(response.Activities); next != nil && n > 0 && response.Activities[n-1].Date.Before(last) {
+			last = response.Activities[n-1].Date
+		}
+		response.Activities = fillDays(response.Activities, first, last)
+	}
+
+	return spec.GetTripsTripIDActivitiesJSON200Response(response)
+}
+
+// Report the conflicts between the activities of a trip.
+// (GET /trips/{tripId}/activities/conflicts)
+func (api API) GetTripsTripIDActivitiesConflicts(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionRead)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	activities, err := api.store.GetTripActivities(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip activities", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	pairs := overlaps(activities)
+	orphans := outOfRange(trip, activities)
+	response := spec.ActivityConflictsResponse{
+		Overlaps:   make([]spec.ActivityOverlap, len(pairs)),
+		OutOfRange: make([]spec.GetTripActivitiesResponseInnerArray, len(orphans)),
+	}
+	for i, pair := range pairs {
+		response.Overlaps[i] = spec.ActivityOverlap{Activities: []spec.GetTripActivitiesResponseInnerArray{
+			activityDetails(trip, pair[0]),
+			activityDetails(trip, pair[1]),
+		}}
+	}
+	for i, activity := range orphans {
+		response.OutOfRange[i] = activityDetails(trip, activity)
+	}
+
+	return spec.GetTripsTripIDActivitiesConflictsJSON200Response(response)
+}
+
+// Create a trip activity.
+// (POST /trips/{tripId}/activities)
+func (api API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string, params spec.PostTripsTripIDActivitiesParams) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PostTripsTripIDActivitiesJSONRequestBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	reject, err := rejectOverlaps((*string)(params.OnOverlap))
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid value for parameter %q", "on_overlap")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionEdit)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can add activities")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	if body.EndsAt != nil && !body.EndsAt.After(body.OccursAt) {
+		return api.invalidField(w, r, "ends_at", "after_start", "Activity must end after it starts")
+	}
+
+	create := activityParams(id, spec.CreateActivityRequest(body))
+	activity := newActivity(uuid.UUID{}, create)
+
+	var overlapping []pgstore.Activity
+	activityId, err := api.store.CreateActivityChecked(r.Context(), api.pool, create, checkActivity(activity, reject, &overlapping))
+
+	if err != nil {
+		if errors.Is(err, errOutsideTrip) {
+			return api.invalidField(w, r, "occurs_at", "within_trip", "Activity must happen during the trip")
+		}
+		if errors.Is(err, errOverlapRejected) {
+			return api.problem(w, r, problemConflict, "Activities overlapping this one: %d", len(overlapping))
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		api.logger.Error("Failed to create activity", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	response := spec.CreateActivityResponse{
+		ActivityID:            activityId.String(),
+		OverlappingActivities: make([]spec.GetTripActivitiesResponseInnerArray, len(overlapping)),
+	}
+	for i, other := range overlapping {
+		response.OverlappingActivities[i] = activityDetails(trip, other)
+	}
+
+	return spec.PostTripsTripIDActivitiesJSON201Response(response)
+}
+
+// Update a trip activity.
+// (PUT /trips/{tripId}/activities/{activityId})
+func (api API) PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string, params spec.PutTripsTripIDActivitiesActivityIDParams) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PutTripsTripIDActivitiesActivityIDJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	activityId, err := uuid.Parse(activityID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	reject, err := rejectOverlaps((*string)(params.OnOverlap))
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid value for parameter %q", "on_overlap")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionEdit)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can update activities")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	if body.EndsAt != nil && !body.EndsAt.After(body.OccursAt) {
+		return api.invalidField(w, r, "ends_at", "after_start", "Activity must end after it starts")
+	}
+
+	update := activityParams(id, spec.CreateActivityRequest(body))
+	activity := newActivity(activityId, update)
+
+	var overlapping []pgstore.Activity
+	updated, err := api.store.UpdateActivityChecked(r.Context(), api.pool, pgstore.UpdateActivityParams{
+		Title:     update.Title,
+		OccursAt:  update.OccursAt,
+		TimeZone:  update.TimeZone,
+		EndsAt:    update.EndsAt,
+		Location:  update.Location,
+		Latitude:  update.Latitude,
+		Longitude: update.Longitude,
+		Category:  update.Category,
+		Notes:     update.Notes,
+		ID:        activityId,
+		TripID:    id,
+	}, checkActivity(activity, reject, &overlapping))
+	if err != nil {
+		if errors.Is(err, errOutsideTrip) {
+			return api.invalidField(w, r, "occurs_at", "within_trip", "Activity must happen during the trip")
+		}
+		if errors.Is(err, errOverlapRejected) {
+			return api.problem(w, r, problemConflict, "Activities overlapping this one: %d", len(overlapping))
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		api.logger.Error("Failed to update activity", zap.Error(err), zap.String("trip_id", tripID), zap.String("activity_id", activityID))
+		return api.internalError(w, r)
+	}
+
+	if updated == 0 {
+		return api.problem(w, r, problemNotFound, "Activity not found")
+	}
+
+	response := spec.UpdateActivityResponse{
+		OverlappingActivities: make([]spec.GetTripActivitiesResponseInnerArray, len(overlapping)),
+	}
+	for i, other := range overlapping {
+		response.OverlappingActivities[i] = activityDetails(trip, other)
+	}
+
+	return spec.PutTripsTripIDActivitiesActivityIDJSON200Response(response)
+}
+
+// Delete a trip activity.
+// (DELETE /trips/{tripId}/activities/{activityId})
+func (api API) DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	activityId, err := uuid.Parse(activityID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionEdit); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can delete activities")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	_, err = api.store.DeleteActivityAndNotify(r.Context(), api.pool, pgstore.DeleteActivityParams{
+		ID:     activityId,
+		TripID: id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Activity not found")
+		}
+		api.logger.Error("Failed to delete activity", zap.Error(err), zap.String("trip_id", tripID), zap.String("activity_id", activityID))
+		return api.internalError(w, r)
+	}
+
+	return spec.DeleteTripsTripIDActivitiesActivityIDJSON204Response(nil)
+}
+
+// Confirm a trip and send e-mail invitations.
+// (GET /trips/{tripId}/confirm)
+func (api API) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionManage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can confirm it")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	if trip.IsConfirmed {
+		return api.problem(w, r, problemConflict, "Trip is already confirmed")
+	}
+
+	confirmed, err := api.store.ConfirmTripAndNotify(r.Context(), api.pool, id)
+	if err != nil {
+		api.logger.Error("Failed to confirm trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	if !confirmed {
+		return api.problem(w, r, problemConflict, "Trip is already confirmed")
+	}
+
+	return spec.GetTripsTripIDConfirmJSON204Response(nil)
+}
+
+// Get the balances of a trip and the transfers that settle them.
+// (GET /trips/{tripId}/balances)
+func (api API) GetTripsTripIDBalances(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionRead); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	participants, err := api.store.GetParticipants(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip participants", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	paid, err := api.store.GetTripPaidTotals(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip paid totals", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	owed, err := api.store.GetTripOwedTotals(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip owed totals", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	balances, err := tripBalances(participants, paid, owed)
+	if err != nil {
+		api.logger.Error("Failed to compute trip balances", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.GetTripsTripIDBalancesJSON200Response(spec.GetBalancesResponse{Balances: balances})
+}
+
+// Get the budget of a trip against its expenses, by activity category.
+// (GET /trips/{tripId}/budget)
+func (api API) GetTripsTripIDBudget(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionRead); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	budget, err := api.store.GetBudget(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Budget not found")
+		}
+		api.logger.Error("Failed to get trip budget", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	categories, err := api.store.GetBudgetCategories(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get budget categories", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	spending, err := api.store.GetTripSpending(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip spending", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	report, err := budgetReport(r.Context(), api.rates, budget, categories, spending)
+	if err != nil {
+		var missing *missingRateError
+		if errors.As(err, &missing) {
+			return api.problem(w, r, problemConflict, "No exchange rate from %s to %s on %s", missing.from, missing.to, missing.on.Format(time.DateOnly))
+		}
+		api.logger.Error("Failed to report trip budget", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.GetTripsTripIDBudgetJSON200Response(report)
+}
+
+// Set the budget of a trip.
+// (PUT /trips/{tripId}/budget)
+func (api API) PutTripsTripIDBudget(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PutTripsTripIDBudgetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	budget, categories, budgetErr := budgetParams(id, spec.UpdateBudgetRequest(body))
+	if budgetErr != nil {
+		return api.invalidField(w, r, budgetErr.field, budgetErr.rule, budgetErr.message)
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionManage); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can set the budget")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	if err := api.store.SetBudget(r.Context(), api.pool, budget, categories); err != nil {
+		api.logger.Error("Failed to set trip budget", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.PutTripsTripIDBudgetJSON204Response(nil)
+}
+
+// Get a trip expenses.
+// (GET /trips/{tripId}/expenses)
+func (api API) GetTripsTripIDExpenses(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDExpensesParams) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	pg, err := newPage(params.Limit, params.Cursor)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid limit or cursor")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionRead); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	expenses, err := api.store.ListTripExpenses(r.Context(), pgstore.ListTripExpensesParams{
+		TripID:         id,
+		AfterCreatedAt: pg.afterTimestamptz(),
+		AfterID:        pg.afterID(),
+		RowLimit:       pg.rowLimit(),
+	})
+	if err != nil {
+		api.logger.Error("Failed to get trip expenses", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	expenses, next := paginate(pg, expenses, func(expense pgstore.Expense) cursor {
+		return cursor{At: expense.CreatedAt.Time, ID: expense.ID}
+	})
+
+	ids := make([]uuid.UUID, len(expenses))
+	for i, expense := range expenses {
+		ids[i] = expense.ID
+	}
+
+	shares, err := api.store.GetExpenseShares(r.Context(), ids)
+	if err != nil {
+		api.logger.Error("Failed to get expense shares", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	sharesOf := make(map[uuid.UUID][]pgstore.ExpenseShare, len(expenses))
+	for _, share := range shares {
+		sharesOf[share.ExpenseID] = append(sharesOf[share.ExpenseID], share)
+	}
+
+	response := spec.GetExpensesResponse{
+		Expenses:   make([]spec.GetExpensesResponseArray, len(expenses)),
+		NextCursor: next,
+	}
+	for i, expense := range expenses {
+		response.Expenses[i], err = expenseDetails(expense, sharesOf[expense.ID])
+		if err != nil {
+			api.logger.Error("Failed to read expense amounts", zap.Error(err), zap.String("expense_id", expense.ID.String()))
+			return api.internalError(w, r)
+		}
+	}
+
+	return spec.GetTripsTripIDExpensesJSON200Response(response)
+}
+
+// Create a trip expense.
+// (POST /trips/{tripId}/expenses)
+func (api API) PostTripsTripIDExpenses(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PostTripsTripIDExpensesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	amount, amountErr := parseAmount("amount", body.Amount, body.Currency)
+	if amountErr == nil && amount == 0 {
+		amountErr = &splitError{"amount", "min", "Amount must be greater than zero"}
+	}
+	if amountErr != nil {
+		return api.invalidField(w, r, amountErr.field, amountErr.rule, amountErr.message)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionEdit); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can add expenses")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	participants, err := api.store.GetParticipants(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip participants", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	payerId := uuid.MustParse(body.PayerID)
+	payerInTrip := false
+	for _, participant := range participants {
+		payerInTrip = payerInTrip || (participant.ID == payerId && !participant.Removed())
+	}
+	if !payerInTrip {
+		return api.invalidField(w, r, "payer_id", "trip_participant", "Participant is not part of the trip")
+	}
+
+	expense := pgstore.InsertExpenseParams{
+		TripID:      id,
+		PayerID:     payerId,
+		Description: body.Description,
+		Amount:      money.Numeric(amount, body.Currency),
+		Currency:    body.Currency,
+		Split:       body.Split,
+	}
+
+	if body.ActivityID != nil {
+		activityId := uuid.MustParse(*body.ActivityID)
+		if _, err := api.store.GetTripActivity(r.Context(), pgstore.GetTripActivityParams{
+			ID:     activityId,
+			TripID: id,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return api.invalidField(w, r, "activity_id", "trip_activity", "Activity is not part of the trip")
+			}
+			api.logger.Error("Failed to get activity", zap.Error(err), zap.String("trip_id", tripID), zap.String("activity_id", *body.ActivityID))
+			return api.internalError(w, r)
+		}
+		expense.ActivityID = pgtype.UUID{Bytes: activityId, Valid: true}
+	}
+
+	shares, splitErr := expenseShares(spec.CreateExpenseRequest(body), amount, participants)
+	if splitErr != nil {
+		return api.invalidField(w, r, splitErr.field, splitErr.rule, splitErr.message)
+	}
+
+	expenseId, err := api.store.CreateExpense(r.Context(), api.pool, expense, shares)
+	if err != nil {
+		api.logger.Error("Failed to create expense", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.PostTripsTripIDExpensesJSON201Response(spec.CreateExpenseResponse{ExpenseID: expenseId.String()})
+}
+
+// Delete a trip expense.
+// (DELETE /trips/{tripId}/expenses/{expenseId})
+func (api API) DeleteTripsTripIDExpensesExpenseID(w http.ResponseWriter, r *http.Request, tripID string, expenseID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	expenseId, err := uuid.Parse(expenseID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionEdit); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can delete expenses")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	deleted, err := api.store.DeleteExpense(r.Context(), pgstore.DeleteExpenseParams{
+		ID:     expenseId,
+		TripID: id,
+	})
+	if err != nil {
+		api.logger.Error("Failed to delete expense", zap.Error(err), zap.String("trip_id", tripID), zap.String("expense_id", expenseID))
+		return api.internalError(w, r)
+	}
+
+	if deleted == 0 {
+		return api.problem(w, r, problemNotFound, "Expense not found")
+	}
+
+	return spec.DeleteTripsTripIDExpensesExpenseIDJSON204Response(nil)
+}
+
+// Invite someone to the trip.
+// (POST /trips/{tripId}/invites)
+func (api API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PostTripsTripIDInvitesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionManage); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can invite participants")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	var removed *pgstore.Participant
+	participant, err := api.store.GetTripParticipantByEmail(r.Context(), pgstore.GetTripParticipantByEmailParams{
+		TripID: id,
+		Email:  string(body.Email),
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	case !canTransition(participant.Status, pgstore.RSVPInvited):
+		return api.problem(w, r, problemConflict, "Participant has already joined the trip")
+	default:
+		removed = &participant
+	}
+
+	// the owner is the one inviting, so their locale is the best guess
+	locale := user.Locale
+	if body.Locale != nil {
+		locale = *body.Locale
+	}
+
+	var name pgtype.Text
+	if body.Name != nil {
+		name = pgtype.Text{String: *body.Name, Valid: true}
+	}
+
+	// participants removed before are invited again, keeping their expenses
+	if removed != nil {
+		reinvited, err := api.store.ReinviteParticipantAndNotify(r.Context(), api.pool, id, pgstore.ReinviteParticipantParams{
+			ID:     removed.ID,
+			Name:   name,
+			Locale: locale,
+		})
+		if err != nil {
+			api.logger.Error("Failed to invite participant again for the trip",
+				zap.Error(err),
+				zap.String("trip_id", tripID),
+				zap.String("participant_id", removed.ID.String()),
+			)
+			return api.internalError(w, r)
+		}
+		if !reinvited {
+			return api.problem(w, r, problemConflict, "Participant has already joined the trip")
+		}
+		return spec.PostTripsTripIDInvitesJSON201Response(nil)
+	}
+
+	participantId, err := api.store.InviteParticipantAndNotify(r.Context(), api.pool, pgstore.InviteParticipantToTripParams{
+		TripID: id,
+		Email:  string(body.Email),
+		Locale: locale,
+		Name:   name,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, pgstore.ErrParticipantExists) {
+			return api.problem(w, r, problemConflict, "Participant has already joined the trip")
+		}
+		api.logger.Error("Failed to invite participant for the trip",
+			zap.Error(err),
+			zap.String("trip_id", tripID),
+			zap.String("participant_id", participantId.String()),
+			zap.String("participant_email", string(body.Email)),
+		)
+		return api.internalError(w, r)
+	}
+
+	return spec.PostTripsTripIDInvitesJSON201Response(nil)
+}
+
+// Invite many people to the trip at once.
+// (POST /trips/{tripId}/invites/bulk)
+func (api API) PostTripsTripIDInvitesBulk(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	// the owner is the one inviting, so their locale is the best guess
+	locale := user.Locale
+	var entries []inviteEntry
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		var err error
+		entries, err = readInviteCSV(http.MaxBytesReader(w, r.Body, maxInviteUploadSize))
+		if errors.Is(err, errTooManyInvites) {
+			return api.invalidField(w, r, "emails", "max", "At most 500 addresses can be invited at once")
+		}
+		if err != nil {
+			return api.problem(w, r, problemInvalidRequest, "Invalid CSV body")
+		}
+		if len(entries) == 0 {
+			return api.invalidField(w, r, "emails", "required", "The CSV file has no addresses")
+		}
+	} else {
+		var body spec.PostTripsTripIDInvitesBulkJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+		}
+
+		if err := api.validator.Struct(body); err != nil {
+			return api.validationFailed(w, r, err)
+		}
+
+		if body.Locale != nil {
+			locale = *body.Locale
+		}
+		for _, email := range body.Emails {
+			entries = append(entries, inviteEntry{input: email})
+		}
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionManage); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can invite participants")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	// each address is reported once, under the first way it was written
+	results := make([]spec.BulkInviteResult, 0, len(entries))
+	invites := make([]pgstore.InviteParticipantsToTripParams, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		invitee, reason := normalizeInvitee(entry, locale)
+		if reason != "" {
+			reason = i18n.FromContext(r.Context()).Sprintf(reason)
+			results = append(results, spec.BulkInviteResult{
+				Input:   entry.input,
+				Outcome: inviteInvalid,
+				Reason:  &reason,
+			})
+			continue
+		}
+
+		if seen[invitee.email] {
+			continue
+		}
+		seen[invitee.email] = true
+
+		email := types.Email(invitee.email)
+		results = append(results, spec.BulkInviteResult{Input: entry.input, Email: &email})
+		invites = append(invites, pgstore.InviteParticipantsToTripParams{
+			TripID: id,
+			Email:  invitee.email,
+			Locale: invitee.locale,
+			Name:   invitee.name,
+		})
+	}
+
+	invited, err := api.store.InviteParticipantsAndNotify(r.Context(), api.pool, id, invites)
+	if err != nil {
+		if errors.Is(err, pgstore.ErrParticipantExists) {
+			return api.problem(w, r, problemConflict, "Some addresses were invited in the meantime, try again")
+		}
+		api.logger.Error("Failed to invite participants for the trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	wasInvited := make(map[string]bool, len(invited))
+	for _, email := range invited {
+		wasInvited[email] = true
+	}
+
+	for i, result := range results {
+		switch {
+		case result.Email == nil:
+		case wasInvited[string(*result.Email)]:
+			results[i].Outcome = inviteInvited
+		default:
+			results[i].Outcome = inviteAlreadyPresent
+		}
+	}
+
+	return spec.PostTripsTripIDInvitesBulkJSON200Response(spec.BulkInviteResponse{
+		Results:      results,
+		QueuedEmails: len(invited),
+	})
+}
+
+// Get a trip links.
+// (GET /trips/{tripId}/links)
+func (api API) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDLinksParams) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	pg, err := newPage(params.Limit, params.Cursor)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid limit or cursor")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionRead); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	links, err := api.store.ListTripLinks(r.Context(), pgstore.ListTripLinksParams{
+		TripID:         id,
+		AfterCreatedAt: pg.afterTimestamptz(),
+		AfterID:        pg.afterID(),
+		RowLimit:       pg.rowLimit(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		api.logger.Error("Failed to get trip links", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	links, next := paginate(pg, links, func(link pgstore.Link) cursor {
+		return cursor{At: link.CreatedAt.Time, ID: link.ID}
+	})
+
+	response := spec.GetLinksResponse{
+		Links:      make([]spec.GetLinksResponseArray, 0, len(links)),
+		NextCursor: next,
+	}
+	// format items for response
+	for _, link := range links {
+		response.Links = append(response.Links, spec.GetLinksResponseArray{
+			ID:    link.ID.String(),
+			Title: link.Title,
+			URL:   link.Url,
+		})
+	}
+
+	return spec.GetTripsTripIDLinksJSON200Response(response)
+}
+
+// Create a trip link.
+// (POST /trips/{tripId}/links)
+func (api API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PostTripsTripIDLinksJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionEdit); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can add links")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	linkId, err := api.store.CreateTripLink(r.Context(), pgstore.CreateTripLinkParams{
+		TripID: id,
+		Title:  body.Title,
+		Url:    body.URL,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		api.logger.Error("Failed to insert link in trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	return spec.PostTripsTripIDLinksJSON201Response(spec.CreateLinkResponse{LinkID: linkId.String()})
+}
+
+// Update a trip link.
+// (PUT /trips/{tripId}/links/{linkId})
+func (api API) PutTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PutTripsTripIDLinksLinkIDJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	linkId, err := uuid.Parse(linkID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionEdit); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can update links")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	updated, err := api.store.UpdateTripLink(r.Context(), pgstore.UpdateTripLinkParams{
+		Title:  body.Title,
+		Url:    body.URL,
+		ID:     linkId,
+		TripID: id,
+	})
+	if err != nil {
+		api.logger.Error("Failed to update link", zap.Error(err), zap.String("trip_id", tripID), zap.String("link_id", linkID))
+		return api.internalError(w, r)
+	}
+
+	if updated == 0 {
+		return api.problem(w, r, problemNotFound, "Link not found")
+	}
+
+	return spec.PutTripsTripIDLinksLinkIDJSON204Response(nil)
+}
+
+// Delete a trip link.
+// (DELETE /trips/{tripId}/links/{linkId})
+func (api API) DeleteTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	linkId, err := uuid.Parse(linkID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionEdit); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only confirmed participants can delete links")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	_, err = api.store.DeleteTripLinkAndNotify(r.Context(), api.pool, pgstore.DeleteTripLinkParams{
+		ID:     linkId,
+		TripID: id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Link not found")
+		}
+		api.logger.Error("Failed to delete link", zap.Error(err), zap.String("trip_id", tripID), zap.String("link_id", linkID))
+		return api.internalError(w, r)
+	}
+
+	return spec.DeleteTripsTripIDLinksLinkIDJSON204Response(nil)
+}
+
+// Hand a trip over to another participant.
+// (PUT /trips/{tripId}/owner)
+func (api API) PutTripsTripIDOwner(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	var body spec.PutTripsTripIDOwnerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionManage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can transfer the trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), uuid.MustParse(body.ParticipantID))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", body.ParticipantID))
+		return api.internalError(w, r)
+	}
+	if err != nil || participant.TripID != id {
+		return api.problem(w, r, problemNotFound, "Participant not found")
+	}
+
+	if role(participant.Role) == roleOwner {
+		return api.problem(w, r, problemConflict, "Participant already owns the trip")
+	}
+	if !participant.Accepted() {
+		return api.problem(w, r, problemConflict, "Only participants who accepted the invitation can own the trip")
+	}
+
+	// trips are owned by accounts, so the participant must have signed up
+	owner, err := api.store.GetUserByEmail(r.Context(), participant.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemConflict, "Participant has no account yet")
+		}
+		api.logger.Error("Failed to get user", zap.Error(err), zap.String("participant_id", body.ParticipantID))
+		return api.internalError(w, r)
+	}
+
+	if err := api.store.TransferTripOwnership(r.Context(), api.pool, trip, participant, owner); err != nil {
+		api.logger.Error("Failed to transfer trip", zap.Error(err), zap.String("trip_id", tripID), zap.String("participant_id", body.ParticipantID))
+		return api.internalError(w, r)
+	}
+
+	return spec.PutTripsTripIDOwnerJSON204Response(nil)
+}
+
+// Get a trip participants.
+// (GET /trips/{tripId}/participants)
+func (api API) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDParticipantsParams) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	pg, err := newPage(params.Limit, params.Cursor)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid limit or cursor")
+	}
+
+	if _, err := api.authorize(r.Context(), user, id, actionRead); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "You are not part of this trip")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	participants, err := api.store.ListTripParticipants(r.Context(), pgstore.ListTripParticipantsParams{
+		TripID:         id,
+		AfterCreatedAt: pg.afterTimestamptz(),
+		AfterID:        pg.afterID(),
+		RowLimit:       pg.rowLimit(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		api.logger.Error("Failed to get trip participants", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	participants, next := paginate(pg, participants, func(participant pgstore.Participant) cursor {
+		return cursor{At: participant.CreatedAt.Time, ID: participant.ID}
+	})
+
+	response := spec.GetTripParticipantsResponse{NextCursor: next}
+	response.Participants = make([]spec.GetTripParticipantsResponseArray, len(participants))
+
+	for i, participant := range participants {
+		response.Participants[i] = spec.GetTripParticipantsResponseArray{
+			ID:        participant.ID.String(),
+			Email:     types.Email(participant.Email),
+			Status:    participant.Status,
+			Role:      participant.Role,
+			Locale:    participant.Locale,
+			InvitedAt: participant.CreatedAt.Time,
+		}
+		if participant.Name.Valid {
+			response.Participants[i].Name = &participant.Name.String
+		}
+		if participant.RespondedAt.Valid {
+			response.Participants[i].RespondedAt = &participant.RespondedAt.Time
+		}
+		if participant.RemovedAt.Valid {
+			response.Participants[i].RemovedAt = &participant.RemovedAt.Time
+		}
+	}
+
+	return spec.GetTripsTripIDParticipantsJSON200Response(response)
+}
+
+// Remove a participant from a trip.
+// (DELETE /trips/{tripId}/participants/{participantId})
+func (api API) DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *spec.Response {
+	user, ok := currentUser(r)
+	if !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	participantId, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid UUID")
+	}
+
+	trip, err := api.authorize(r.Context(), user, id, actionManage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemNotFound, "Trip not found")
+		}
+		if errors.Is(err, errForbidden) {
+			return api.problem(w, r, problemForbidden, "Only the trip owner can remove participants")
+		}
+		api.logger.Error("Failed to authorize user", zap.Error(err), zap.String("trip_id", tripID))
+		return api.internalError(w, r)
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), participantId)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
+	}
+	if err != nil || participant.TripID != id {
+		return api.problem(w, r, problemNotFound, "Participant not found")
+	}
+
+	if role(participant.Role) == roleOwner {
+		return api.problem(w, r, problemConflict, "The trip owner cannot be removed")
+	}
+
+	if !canTransition(participant.Status, pgstore.RSVPRemoved) {
+		return api.problem(w, r, problemConflict, "Participant was already removed")
+	}
+
+	_, err = api.store.RemoveParticipantAndNotify(r.Context(), api.pool, trip, pgstore.RemoveParticipantParams{
+		ID:     participantId,
+		TripID: id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemConflict, "Participant was already removed")
+		}
+		api.logger.Error("Failed to remove participant", zap.Error(err), zap.String("trip_id", tripID), zap.String("participant_id", participantID))
+		return api.internalError(w, r)
+	}
+
+	return spec.DeleteTripsTripIDParticipantsParticipantIDJSON204Response(nil)
+}
+
+// Create a user account.
+// (POST /users)
+func (api API) PostUsers(w http.ResponseWriter, r *http.Request) *spec.Response {
+	var body spec.PostUsersJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+	if err != nil {
+		api.logger.Error("Failed to hash password", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	locale := i18n.FromContext(r.Context())
+	if body.Locale != nil {
+		locale = i18n.Locale(*body.Locale)
+	}
+
+	params := pgstore.CreateUserParams{
+		Email:        normalizeEmail(string(body.Email)),
+		Name:         body.Name,
+		PasswordHash: string(hash),
+		Locale:       string(locale),
+	}
+
+	userId, err := api.store.CreateUser(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.claimAccount(w, r, params)
+		}
+		api.logger.Error("Failed to create user", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	return spec.PostUsersJSON201Response(spec.CreateUserResponse{UserID: userId.String()})
+}
+
+// claimAccount answers a signup with an email that is already registered. The
+// placeholder accounts of the owners of older trips are claimed through a link
+// sent to the email, and the others are taken.
+func (api API) claimAccount(w http.ResponseWriter, r *http.Request, params pgstore.CreateUserParams) *spec.Response {
+	user, err := api.store.GetUserByEmail(r.Context(), params.Email)
+	if err != nil {
+		api.logger.Error("Failed to get user", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	if user.PasswordHash != "" {
+		return api.problem(w, r, problemConflict, "Email already registered")
+	}
+
+	if err := api.store.RequestAccountClaim(r.Context(), api.pool, user, params); err != nil {
+		api.logger.Error("Failed to request account claim", zap.Error(err), zap.String("user_id", user.ID.String()))
+		return api.internalError(w, r)
+	}
+
+	return spec.PostUsersJSON202Response(nil)
+}
+
+// Log in and create a session token.
+// (POST /sessions)
+func (api API) PostSessions(w http.ResponseWriter, r *http.Request) *spec.Response {
+	var body spec.PostSessionsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return api.problem(w, r, problemInvalidRequest, "Invalid JSON body")
+	}
+
+	if err := api.validator.Struct(body); err != nil {
+		return api.validationFailed(w, r, err)
+	}
+
+	user, err := api.store.GetUserByEmail(r.Context(), normalizeEmail(string(body.Email)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, problemUnauthorized, "Invalid email or password")
+		}
+		api.logger.Error("Failed to get user", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(body.Password)); err != nil {
+		return api.problem(w, r, problemUnauthorized, "Invalid email or password")
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		api.logger.Error("Failed to generate session token", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	expiresAt := time.Now().Add(sessionTTL)
+	if err := api.store.CreateSession(r.Context(), pgstore.CreateSessionParams{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	}); err != nil {
+		api.logger.Error("Failed to create session", zap.Error(err), zap.String("user_id", user.ID.String()))
+		return api.internalError(w, r)
+	}
+
+	return spec.PostSessionsJSON201Response(spec.CreateSessionResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// Log out and revoke the current session token.
+// (DELETE /sessions)
+func (api API) DeleteSessions(w http.ResponseWriter, r *http.Request) *spec.Response {
+	if _, ok := currentUser(r); !ok {
+		return api.problem(w, r, problemUnauthorized, "A valid session token is required")
+	}
+
+	token, _ := bearerToken(r)
+	if err := api.store.DeleteSession(r.Context(), hashToken(token)); err != nil {
+		api.logger.Error("Failed to delete session", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	return spec.DeleteSessionsJSON204Response(nil)
 }
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"planner-go/internal/i18n"
+	"planner-go/internal/pgstore"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type contextKey string
+
+const userContextKey contextKey = "user"
+
+// sessionTTL is how long a bearer token stays valid after login.
+const sessionTTL = 30 * 24 * time.Hour
+
+// Authenticate resolves the bearer token of the request, if any, into the user
+// that owns it. Requests without a valid token are passed through untouched, so
+// each handler decides whether it needs an authenticated caller.
+func (api API) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user, err := api.store.GetSessionUser(r.Context(), hashToken(token))
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				api.logger.Error("Failed to get session user", zap.Error(err))
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userContextKey, user)
+
+		// without an Accept-Language, answer in the language the user chose
+		if r.Header.Get("Accept-Language") == "" {
+			locale := i18n.Parse(user.Locale)
+			w.Header().Set("Content-Language", string(locale))
+			ctx = i18n.WithLocale(ctx, locale)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// currentUser returns the user authenticated by Authenticate.
+func currentUser(r *http.Request) (pgstore.User, bool) {
+	user, ok := r.Context().Value(userContextKey).(pgstore.User)
+	return user, ok
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// newToken returns a random token for the client and the hash of it that is
+// stored in the database. It backs sessions and calendar subscriptions.
+func newToken() (string, []byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
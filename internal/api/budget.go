@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"planner-go/internal/api/spec"
+	"planner-go/internal/exchange"
+	"planner-go/internal/money"
+	"planner-go/internal/pgstore"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// budgetCategories are the activity categories in the order the budget report
+// lists them. Expenses without an activity count as other.
+var budgetCategories = []string{"food", "transport", "sightseeing", "lodging", "leisure", "shopping", "other"}
+
+// missingRateError is an expense that cannot be converted to the currency of
+// the budget because no rate is known for its day.
+type missingRateError struct {
+	from, to string
+	on       time.Time
+}
+
+func (e *missingRateError) Error() string {
+	return fmt.Sprintf("api: no exchange rate from %s to %s on %s", e.from, e.to, e.on.Format(time.DateOnly))
+}
+
+// budgetParams reads a budget request into minor units of its currency. The
+// categories are listed once each.
+func budgetParams(tripID uuid.UUID, body spec.UpdateBudgetRequest) (pgstore.UpsertBudgetParams, []pgstore.InsertBudgetCategoriesParams, *splitError) {
+	params := pgstore.UpsertBudgetParams{TripID: tripID, Currency: body.Currency}
+	if body.Total != nil {
+		total, err := parseAmount("total", *body.Total, body.Currency)
+		if err != nil {
+			return params, nil, err
+		}
+		params.Total = money.Numeric(total, body.Currency)
+	}
+
+	categories := make([]pgstore.InsertBudgetCategoriesParams, len(body.Categories))
+	seen := make(map[string]bool, len(body.Categories))
+	for i, category := range body.Categories {
+		if seen[category.Category] {
+			return params, nil, &splitError{fmt.Sprintf("categories[%d].category", i), "unique", "Category is listed more than once"}
+		}
+		seen[category.Category] = true
+
+		amount, err := parseAmount(fmt.Sprintf("categories[%d].amount", i), category.Amount, body.Currency)
+		if err != nil {
+			return params, nil, err
+		}
+		categories[i] = pgstore.InsertBudgetCategoriesParams{
+			TripID:   tripID,
+			Category: category.Category,
+			Amount:   money.Numeric(amount, body.Currency),
+		}
+	}
+
+	return params, categories, nil
+}
+
+// budgetReport sets the budget of a trip against what was spent, converting
+// each expense with the rate of the day it was made in the trip time zone.
+// Converted amounts are added up exactly and rounded once to the currency of
+// the budget.
+func budgetReport(ctx context.Context, rates exchange.Provider, budget pgstore.Budget, planned []pgstore.BudgetCategory, spending []pgstore.GetTripSpendingRow) (spec.GetBudgetResponse, error) {
+	currency := budget.Currency
+
+	plannedOf := make(map[string]int64, len(planned))
+	var plannedSum int64
+	for _, category := range planned {
+		amount, err := money.FromNumeric(category.Amount, currency)
+		if err != nil {
+			return spec.GetBudgetResponse{}, err
+		}
+		plannedOf[category.Category] = amount
+		plannedSum += amount
+	}
+
+	actualOf := make(map[string]*big.Rat)
+	actual := new(big.Rat)
+	for _, row := range spending {
+		rate, err := rates.Rate(ctx, row.Currency, currency, row.SpentOn.Time)
+		if err != nil {
+			if errors.Is(err, exchange.ErrNoRate) {
+				return spec.GetBudgetResponse{}, &missingRateError{row.Currency, currency, row.SpentOn.Time}
+			}
+			return spec.GetBudgetResponse{}, err
+		}
+
+		total, err := money.NumericRat(row.Total)
+		if err != nil {
+			return spec.GetBudgetResponse{}, err
+		}
+		total.Mul(total, rate)
+
+		if actualOf[row.Category] == nil {
+			actualOf[row.Category] = new(big.Rat)
+		}
+		actualOf[row.Category].Add(actualOf[row.Category], total)
+		actual.Add(actual, total)
+	}
+
+	report := spec.GetBudgetResponse{
+		Currency:   currency,
+		Actual:     money.Format(money.FromRat(actual, currency), currency),
+		Categories: []spec.BudgetCategoryReport{},
+	}
+
+	switch {
+	case budget.Total.Valid:
+		total, err := money.FromNumeric(budget.Total, currency)
+		if err != nil {
+			return spec.GetBudgetResponse{}, err
+		}
+		report.Planned, report.Remaining = plannedAmounts(total, money.FromRat(actual, currency), currency)
+	case len(planned) > 0:
+		report.Planned, report.Remaining = plannedAmounts(plannedSum, money.FromRat(actual, currency), currency)
+	}
+
+	for _, category := range budgetCategories {
+		amount, hasPlan := plannedOf[category]
+		spent := actualOf[category]
+		if !hasPlan && spent == nil {
+			continue
+		}
+
+		var actual int64
+		if spent != nil {
+			actual = money.FromRat(spent, currency)
+		}
+
+		line := spec.BudgetCategoryReport{
+			Category: category,
+			Actual:   money.Format(actual, currency),
+		}
+		if hasPlan {
+			line.Planned, line.Remaining = plannedAmounts(amount, actual, currency)
+		}
+		report.Categories = append(report.Categories, line)
+	}
+
+	return report, nil
+}
+
+func plannedAmounts(planned, actual int64, currency string) (*string, *string) {
+	plannedText := money.Format(planned, currency)
+	remainingText := money.Format(planned-actual, currency)
+	return &plannedText, &remainingText
+}
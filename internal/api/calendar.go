@@ -0,0 +1,55 @@
+package api
+
+import (
+	"planner-go/internal/i18n"
+	"planner-go/internal/ical"
+	"planner-go/internal/pgstore"
+	"time"
+)
+
+// calendarRefresh is how often calendar apps subscribed to a trip are asked to
+// poll it again.
+const calendarRefresh = time.Hour
+
+// tripCalendar lays out a trip as an all-day event spanning its days, in its
+// time zone, followed by one event for each of its activities at the instant
+// it happens. UIDs derive from the IDs of the
+// rows, so calendar apps update events in place instead of duplicating them.
+func tripCalendar(trip pgstore.Trip, activities []pgstore.Activity, locale i18n.Locale, now time.Time) ical.Calendar {
+	name := locale.Sprintf("Trip to %s", trip.Destination)
+	loc := trip.Zone()
+
+	events := make([]ical.Event, 0, len(activities)+1)
+	events = append(events, ical.Event{
+		UID:      ical.UID("trip", trip.ID),
+		Stamp:    now,
+		AllDay:   true,
+		Start:    trip.StartsAt.Time.In(loc),
+		End:      trip.EndsAt.Time.In(loc),
+		Summary:  name,
+		Location: trip.Destination,
+	})
+
+	for _, activity := range activities {
+		event := ical.Event{
+			UID:         ical.UID("activity", activity.ID),
+			Stamp:       now,
+			Start:       activity.OccursAt.Time,
+			End:         activity.EndsAt.Time,
+			Summary:     activity.Title,
+			Description: activity.Notes,
+			Location:    activity.Location.String,
+		}
+		if activity.Latitude.Valid && activity.Longitude.Valid {
+			event.Geo = &ical.Geo{Latitude: activity.Latitude.Float64, Longitude: activity.Longitude.Float64}
+		}
+		events = append(events, event)
+	}
+
+	return ical.Calendar{
+		ProdID:          ical.ProdID,
+		Name:            name,
+		RefreshInterval: calendarRefresh,
+		Events:          events,
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+var errInvalidCursor = errors.New("api: invalid cursor")
+
+// cursor marks the last item of a page. Collections are ordered by a time
+// column and then by id, so the pair is enough to resume right after it.
+type cursor struct {
+	At time.Time `json:"at"`
+	ID uuid.UUID `json:"id"`
+}
+
+// page holds the pagination arguments of a collection request.
+type page struct {
+	limit int
+	after *cursor
+}
+
+// newPage validates the limit and cursor query parameters.
+func newPage(limit *int, raw *string) (page, error) {
+	p := page{limit: defaultPageLimit}
+	if limit != nil {
+		if *limit < 1 || *limit > maxPageLimit {
+			return page{}, errors.New("api: limit out of range")
+		}
+		p.limit = *limit
+	}
+
+	if raw != nil && *raw != "" {
+		c, err := decodeCursor(*raw)
+		if err != nil {
+			return page{}, err
+		}
+		p.after = &c
+	}
+
+	return p, nil
+}
+
+// rowLimit is the number of rows to query for the page. One extra row is
+// fetched to know whether there is a next page.
+func (p page) rowLimit() int32 {
+	return int32(p.limit + 1)
+}
+
+func (p page) afterTimestamptz() pgtype.Timestamptz {
+	if p.after == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: p.after.At, Valid: true}
+}
+
+func (p page) afterID() pgtype.UUID {
+	if p.after == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: p.after.ID, Valid: true}
+}
+
+// paginate trims rows fetched with rowLimit down to the page and returns the
+// cursor of the next page, or nil when rows was the last page.
+func paginate[T any](p page, rows []T, key func(T) cursor) ([]T, *string) {
+	if len(rows) <= p.limit {
+		return rows, nil
+	}
+
+	rows = rows[:p.limit]
+	next := encodeCursor(key(rows[len(rows)-1]))
+	return rows, &next
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, errInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == uuid.Nil {
+		return cursor{}, errInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	sao, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+	}{
+		{"utc", time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)},
+		{"microseconds", time.Date(2024, 7, 1, 12, 0, 0, 123456000, time.UTC)},
+		{"offset", time.Date(2024, 7, 1, 9, 30, 0, 0, sao)},
+		{"zero", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := cursor{At: tt.at, ID: uuid.New()}
+
+			got, err := decodeCursor(encodeCursor(want))
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if !got.At.Equal(want.At) || got.ID != want.ID {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"not base64", "not a cursor!"},
+		{"not json", "bm90IGpzb24"},
+		{"missing id", encodeCursor(cursor{At: time.Now()})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.raw); !errors.Is(err, errInvalidCursor) {
+				t.Errorf("got %v, want %v", err, errInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	limit := func(n int) *int { return &n }
+	raw := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		limit   *int
+		raw     *string
+		want    int
+		wantErr bool
+	}{
+		{"defaults", nil, nil, defaultPageLimit, false},
+		{"limit", limit(10), nil, 10, false},
+		{"max limit", limit(maxPageLimit), nil, maxPageLimit, false},
+		{"zero limit", limit(0), nil, 0, true},
+		{"limit over max", limit(maxPageLimit + 1), nil, 0, true},
+		{"empty cursor", nil, raw(""), defaultPageLimit, false},
+		{"invalid cursor", nil, raw("!"), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newPage(tt.limit, tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && p.limit != tt.want {
+				t.Errorf("got limit %d, want %d", p.limit, tt.want)
+			}
+		})
+	}
+}
+
+// TestPaginateWalk pages through rows the way the listings do, with rows
+// after the cursor ordered by time and then by id, and checks every row is
+// seen once even when many share a timestamp.
+func TestPaginateWalk(t *testing.T) {
+	base := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		times []time.Time
+		limit int
+	}{
+		{"distinct times", []time.Time{base, base.Add(time.Second), base.Add(2 * time.Second)}, 2},
+		{"equal times", []time.Time{base, base, base, base, base}, 2},
+		{"equal times across pages", []time.Time{base, base, base.Add(time.Second), base.Add(time.Second), base.Add(time.Second)}, 1},
+		{"exact pages", []time.Time{base, base, base, base}, 2},
+		{"single page", []time.Time{base, base}, 5},
+		{"empty", nil, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := make([]cursor, len(tt.times))
+			for i, at := range tt.times {
+				rows[i] = cursor{At: at, ID: uuid.New()}
+			}
+			sort.Slice(rows, func(i, j int) bool { return cursorLess(rows[i], rows[j]) })
+
+			limit := tt.limit
+			p, err := newPage(&limit, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var seen []cursor
+			for pages := 0; ; pages++ {
+				if pages > len(rows)+1 {
+					t.Fatal("pagination does not end")
+				}
+
+				page, next := paginate(p, fetchAfter(rows, p), func(c cursor) cursor { return c })
+				seen = append(seen, page...)
+				if next == nil {
+					break
+				}
+				if p, err = newPage(&limit, next); err != nil {
+					t.Fatalf("newPage: %v", err)
+				}
+			}
+
+			if len(seen) != len(rows) {
+				t.Fatalf("saw %d rows, want %d", len(seen), len(rows))
+			}
+			for i := range rows {
+				if seen[i].ID != rows[i].ID {
+					t.Errorf("row %d: got %s, want %s", i, seen[i].ID, rows[i].ID)
+				}
+			}
+		})
+	}
+}
+
+// fetchAfter stands for the listing queries: the rows after the cursor of p,
+// up to its row limit.
+func fetchAfter(rows []cursor, p page) []cursor {
+	var found []cursor
+	for _, row := range rows {
+		if p.after != nil && !cursorLess(*p.after, row) {
+			continue
+		}
+		if len(found) == int(p.rowLimit()) {
+			break
+		}
+		found = append(found, row)
+	}
+	return found
+}
+
+func cursorLess(a, b cursor) bool {
+	if !a.At.Equal(b.At) {
+		return a.At.Before(b.At)
+	}
+	return bytes.Compare(a.ID[:], b.ID[:]) < 0
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"planner-go/internal/api/spec"
+	"planner-go/internal/ledger"
+	"planner-go/internal/money"
+	"planner-go/internal/pgstore"
+	"sort"
+
+	"github.com/discord-gophers/goapi-gen/types"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	splitEqual  = "equal"
+	splitShares = "shares"
+	splitExact  = "exact"
+)
+
+// splitError is a split of an expense that passed the struct validation but
+// cannot be applied to the participants of the trip.
+type splitError struct {
+	field   string
+	rule    string
+	message string
+}
+
+func (e *splitError) Error() string {
+	return fmt.Sprintf("api: %s failed the %s rule", e.field, e.rule)
+}
+
+// parseAmount reads a decimal amount of the currency into minor units.
+func parseAmount(field, amount, currency string) (int64, *splitError) {
+	minor, err := money.Parse(amount, currency)
+	if errors.Is(err, money.ErrTooPrecise) {
+		return 0, &splitError{field, "currency_scale", "Amount has more decimal places than the currency"}
+	}
+	if err != nil {
+		return 0, &splitError{field, "numeric", "Amount must be a non-negative decimal number, such as 12.50"}
+	}
+	return minor, nil
+}
+
+// expenseShares works out what each participant owes of an expense of amount
+// minor units. The equal split without splits is shared among every
+// participant of the trip who did not decline or let the invitation expire;
+// otherwise only the listed participants share it, each once. Removed
+// participants share no expenses.
+func expenseShares(body spec.CreateExpenseRequest, amount int64, participants []pgstore.Participant) ([]pgstore.InsertExpenseSharesParams, *splitError) {
+	inTrip := make(map[uuid.UUID]bool, len(participants))
+	for _, participant := range participants {
+		inTrip[participant.ID] = !participant.Removed()
+	}
+
+	splits := body.Splits
+	if len(splits) == 0 {
+		if body.Split != splitEqual {
+			return nil, &splitError{"splits", "required", "Only the equal split can leave out who shares the expense"}
+		}
+		for _, participant := range participants {
+			if participant.Removed() || participant.Status == pgstore.RSVPDeclined || participant.Status == pgstore.RSVPExpired {
+				continue
+			}
+			splits = append(splits, spec.ExpenseSplit{ParticipantID: participant.ID.String()})
+		}
+	}
+	if len(splits) == 0 {
+		return nil, &splitError{"splits", "required", "The trip has no participants to share the expense"}
+	}
+
+	shares := make([]pgstore.InsertExpenseSharesParams, len(splits))
+	weights := make([]int64, len(splits))
+	seen := make(map[uuid.UUID]bool, len(splits))
+	var exact int64
+
+	for i, split := range splits {
+		id, err := uuid.Parse(split.ParticipantID)
+		if err != nil || !inTrip[id] {
+			return nil, &splitError{fmt.Sprintf("splits[%d].participant_id", i), "trip_participant", "Participant is not part of the trip"}
+		}
+		if seen[id] {
+			return nil, &splitError{fmt.Sprintf("splits[%d].participant_id", i), "unique", "Participant is listed more than once"}
+		}
+		seen[id] = true
+		shares[i].ParticipantID = id
+
+		switch body.Split {
+		case splitEqual:
+			weights[i] = 1
+		case splitShares:
+			if split.Shares == nil {
+				return nil, &splitError{fmt.Sprintf("splits[%d].shares", i), "required", "The shares split needs the shares of each participant"}
+			}
+			weights[i] = int64(*split.Shares)
+			shares[i].Shares = pgtype.Int4{Int32: int32(*split.Shares), Valid: true}
+		case splitExact:
+			if split.Amount == nil {
+				return nil, &splitError{fmt.Sprintf("splits[%d].amount", i), "required", "The exact split needs the amount of each participant"}
+			}
+			minor, err := parseAmount(fmt.Sprintf("splits[%d].amount", i), *split.Amount, body.Currency)
+			if err != nil {
+				return nil, err
+			}
+			shares[i].Amount = money.Numeric(minor, body.Currency)
+			exact += minor
+		}
+	}
+
+	if body.Split == splitExact {
+		if exact != amount {
+			return nil, &splitError{"splits", "sum", "Split amounts must add up to the expense amount"}
+		}
+		return shares, nil
+	}
+
+	for i, minor := range ledger.Split(amount, weights) {
+		shares[i].Amount = money.Numeric(minor, body.Currency)
+	}
+	return shares, nil
+}
+
+// formatNumeric writes a stored amount with every decimal place of its
+// currency.
+func formatNumeric(n pgtype.Numeric, currency string) (string, error) {
+	minor, err := money.FromNumeric(n, currency)
+	if err != nil {
+		return "", err
+	}
+	return money.Format(minor, currency), nil
+}
+
+// expenseDetails writes an expense the way it is listed, along with its
+// shares.
+func expenseDetails(expense pgstore.Expense, shares []pgstore.ExpenseShare) (spec.GetExpensesResponseArray, error) {
+	amount, err := formatNumeric(expense.Amount, expense.Currency)
+	if err != nil {
+		return spec.GetExpensesResponseArray{}, err
+	}
+
+	details := spec.GetExpensesResponseArray{
+		ID:          expense.ID.String(),
+		Description: expense.Description,
+		Amount:      amount,
+		Currency:    expense.Currency,
+		PayerID:     expense.PayerID.String(),
+		Split:       expense.Split,
+		Shares:      make([]spec.ExpenseShare, len(shares)),
+		CreatedAt:   expense.CreatedAt.Time,
+	}
+	if expense.ActivityID.Valid {
+		id := uuid.UUID(expense.ActivityID.Bytes).String()
+		details.ActivityID = &id
+	}
+
+	for i, share := range shares {
+		amount, err := formatNumeric(share.Amount, expense.Currency)
+		if err != nil {
+			return spec.GetExpensesResponseArray{}, err
+		}
+		details.Shares[i] = spec.ExpenseShare{
+			ParticipantID: share.ParticipantID.String(),
+			Amount:        amount,
+		}
+		if share.Shares.Valid {
+			n := int(share.Shares.Int32)
+			details.Shares[i].Shares = &n
+		}
+	}
+
+	return details, nil
+}
+
+// tripBalances nets what each participant paid against what they owe, for
+// each currency on its own, and the transfers that settle them. Currencies are
+// sorted by code and participants keep the order they are given in. Removed
+// participants are only listed in the currencies they paid or owe in.
+func tripBalances(participants []pgstore.Participant, paid []pgstore.GetTripPaidTotalsRow, owed []pgstore.GetTripOwedTotalsRow) ([]spec.CurrencyBalance, error) {
+	type totals struct{ paid, owed int64 }
+	byCurrency := make(map[string]map[uuid.UUID]*totals)
+
+	get := func(currency string, id uuid.UUID) *totals {
+		if byCurrency[currency] == nil {
+			byCurrency[currency] = make(map[uuid.UUID]*totals)
+		}
+		if byCurrency[currency][id] == nil {
+			byCurrency[currency][id] = &totals{}
+		}
+		return byCurrency[currency][id]
+	}
+	for _, row := range paid {
+		total, err := money.FromNumeric(row.Total, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		get(row.Currency, row.ParticipantID).paid += total
+	}
+	for _, row := range owed {
+		total, err := money.FromNumeric(row.Total, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		get(row.Currency, row.ParticipantID).owed += total
+	}
+
+	currencies := make([]string, 0, len(byCurrency))
+	for currency := range byCurrency {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	balances := make([]spec.CurrencyBalance, len(currencies))
+	for i, currency := range currencies {
+		nets := make(map[uuid.UUID]int64, len(participants))
+		balance := spec.CurrencyBalance{
+			Currency:     currency,
+			Participants: make([]spec.ParticipantBalance, 0, len(participants)),
+		}
+
+		for _, participant := range participants {
+			if participant.Removed() && byCurrency[currency][participant.ID] == nil {
+				continue
+			}
+			t := get(currency, participant.ID)
+			nets[participant.ID] = t.paid - t.owed
+			balance.Participants = append(balance.Participants, spec.ParticipantBalance{
+				ParticipantID: participant.ID.String(),
+				Email:         types.Email(participant.Email),
+				Paid:          money.Format(t.paid, currency),
+				Owed:          money.Format(t.owed, currency),
+				Net:           money.Format(t.paid-t.owed, currency),
+			})
+		}
+
+		transfers := ledger.Settle(nets)
+		balance.Transfers = make([]spec.SettleUpTransfer, len(transfers))
+		for j, transfer := range transfers {
+			balance.Transfers[j] = spec.SettleUpTransfer{
+				FromParticipantID: transfer.From.String(),
+				ToParticipantID:   transfer.To.String(),
+				Amount:            money.Format(transfer.Amount, currency),
+			}
+		}
+
+		balances[i] = balance
+	}
+
+	return balances, nil
+}
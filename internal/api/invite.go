@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/mail"
+	"planner-go/internal/i18n"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	inviteInvited        = "invited"
+	inviteAlreadyPresent = "already_present"
+	inviteInvalid        = "invalid"
+)
+
+// maxInvites bounds the addresses of a bulk invitation, as the JSON body
+// already does through its validation.
+const maxInvites = 500
+
+// maxInviteUploadSize bounds the CSV files accepted by PostTripsTripIDInvitesBulk.
+const maxInviteUploadSize = 1 << 20
+
+var errTooManyInvites = errors.New("api: too many addresses to invite")
+
+// inviteEntry is an address of a bulk invitation as it was sent, along with
+// the name and locale given to it.
+type inviteEntry struct {
+	input  string
+	name   string
+	locale string
+}
+
+// invitee is an entry of a bulk invitation once its address is normalized.
+type invitee struct {
+	email  string
+	name   pgtype.Text
+	locale string
+}
+
+// readInviteCSV reads the email, name and locale columns of a CSV upload, the
+// last two being optional. A first row naming the columns is skipped.
+func readInviteCSV(r io.Reader) ([]inviteEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []inviteEntry
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "email") {
+			continue
+		}
+
+		if len(entries) == maxInvites {
+			return nil, errTooManyInvites
+		}
+
+		entry := inviteEntry{input: record[0]}
+		if len(record) > 1 {
+			entry.name = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 {
+			entry.locale = strings.TrimSpace(record[2])
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// normalizeInvitee reads the address of an entry, either bare or as
+// Name <address>, and lower-cases it so that it is compared ignoring case. The
+// name of the column wins over the one of the address. It returns the reason
+// the entry is invalid otherwise.
+func normalizeInvitee(entry inviteEntry, defaultLocale string) (invitee, string) {
+	input := strings.TrimSpace(entry.input)
+	if input == "" {
+		return invitee{}, "Address is empty"
+	}
+
+	address, err := mail.ParseAddress(input)
+	if err != nil {
+		return invitee{}, "Address is not a valid e-mail"
+	}
+
+	name := entry.name
+	if name == "" {
+		name = address.Name
+	}
+	if len(name) > 255 {
+		return invitee{}, "Name must be at most 255 characters long"
+	}
+
+	locale := defaultLocale
+	if entry.locale != "" {
+		if !supportedLocale(entry.locale) {
+			return invitee{}, "Locale must be one of en, pt-BR or es"
+		}
+		locale = entry.locale
+	}
+
+	return invitee{
+		email:  strings.ToLower(address.Address),
+		name:   pgtype.Text{String: name, Valid: name != ""},
+		locale: locale,
+	}, ""
+}
+
+func supportedLocale(locale string) bool {
+	for _, supported := range i18n.Supported {
+		if string(supported) == locale {
+			return true
+		}
+	}
+	return false
+}
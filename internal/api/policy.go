@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"planner-go/internal/pgstore"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type role string
+
+const (
+	roleViewer role = "viewer"
+	roleEditor role = "editor"
+	roleOwner  role = "owner"
+)
+
+type action int
+
+const (
+	// actionRead covers looking at a trip and everything attached to it.
+	actionRead action = iota
+	// actionEdit covers adding activities and links to a trip.
+	actionEdit
+	// actionManage covers changing the trip itself and who takes part in it.
+	actionManage
+)
+
+var errForbidden = errors.New("api: user is not allowed to perform this action")
+
+func (rl role) can(a action) bool {
+	switch rl {
+	case roleOwner:
+		return true
+	case roleEditor:
+		return a <= actionEdit
+	case roleViewer:
+		return a == actionRead
+	}
+	return false
+}
+
+// tripRole resolves the role of user in trip from their participant row, the
+// owner included, see participantRole.
+func (api API) tripRole(ctx context.Context, user pgstore.User, trip pgstore.Trip) (role, error) {
+	participant, err := api.store.GetTripParticipantByEmail(ctx, pgstore.GetTripParticipantByEmailParams{
+		TripID: trip.ID,
+		Email:  user.Email,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", errForbidden
+		}
+		return "", fmt.Errorf("api: failed to get participant for tripRole: %w", err)
+	}
+
+	return participantRole(participant)
+}
+
+// participantRole is the role participant has in their trip. Those who did not
+// accept the invitation yet are treated as viewers whatever role they were
+// given, while those removed from the trip, who declined it or let the
+// invitation expire have no access at all.
+func participantRole(participant pgstore.Participant) (role, error) {
+	switch participant.Status {
+	case pgstore.RSVPRemoved, pgstore.RSVPDeclined, pgstore.RSVPExpired:
+		return "", errForbidden
+	case pgstore.RSVPAccepted:
+		return role(participant.Role), nil
+	}
+	return roleViewer, nil
+}
+
+// authorize loads the trip and checks that user may perform a on it. It
+// returns pgx.ErrNoRows when the trip does not exist and errForbidden when the
+// user has no access or not enough of it.
+func (api API) authorize(ctx context.Context, user pgstore.User, tripID uuid.UUID, a action) (pgstore.Trip, error) {
+	trip, err := api.store.GetTrip(ctx, tripID)
+	if err != nil {
+		return pgstore.Trip{}, err
+	}
+
+	rl, err := api.tripRole(ctx, user, trip)
+	if err != nil {
+		return pgstore.Trip{}, err
+	}
+
+	if !rl.can(a) {
+		return pgstore.Trip{}, errForbidden
+	}
+	return trip, nil
+}
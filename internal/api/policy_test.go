@@ -0,0 +1,38 @@
+package api
+
+import (
+	"errors"
+	"planner-go/internal/pgstore"
+	"testing"
+)
+
+func TestParticipantRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		role    string
+		want    role
+		wantErr error
+	}{
+		{"owner", pgstore.RSVPAccepted, "owner", roleOwner, nil},
+		{"accepted editor", pgstore.RSVPAccepted, "editor", roleEditor, nil},
+		{"accepted viewer", pgstore.RSVPAccepted, "viewer", roleViewer, nil},
+		{"invited editor", pgstore.RSVPInvited, "editor", roleViewer, nil},
+		{"maybe editor", pgstore.RSVPMaybe, "editor", roleViewer, nil},
+		{"declined", pgstore.RSVPDeclined, "editor", "", errForbidden},
+		{"expired", pgstore.RSVPExpired, "editor", "", errForbidden},
+		{"removed", pgstore.RSVPRemoved, "editor", "", errForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := participantRole(pgstore.Participant{Status: tt.status, Role: tt.role})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
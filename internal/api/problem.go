@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"planner-go/internal/api/spec"
+	"planner-go/internal/i18n"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+const problemContentType = "application/problem+json"
+
+// problemKind is a class of failure. Its type URI is stable so clients can
+// branch on it instead of parsing the detail message.
+type problemKind struct {
+	slug   string
+	status int
+	title  string
+}
+
+var (
+	problemInvalidRequest   = problemKind{"invalid-request", http.StatusBadRequest, "Invalid request"}
+	problemUnauthorized     = problemKind{"unauthorized", http.StatusUnauthorized, "Unauthorized"}
+	problemForbidden        = problemKind{"forbidden", http.StatusForbidden, "Forbidden"}
+	problemNotFound         = problemKind{"not-found", http.StatusNotFound, "Not found"}
+	problemConflict         = problemKind{"conflict", http.StatusConflict, "Conflict"}
+	problemValidationFailed = problemKind{"validation-failed", http.StatusUnprocessableEntity, "Validation failed"}
+	problemInternal         = problemKind{"internal-error", http.StatusInternalServerError, "Internal server error"}
+)
+
+func (k problemKind) typeURI() string {
+	return "urn:planner:problem:" + k.slug
+}
+
+// problem writes an RFC 7807 body for kind. detail is an English format, as
+// for fmt.Sprintf, and is translated to the locale of the request along with
+// the title. It returns a nil response, which tells the generated wrapper that
+// the handler already wrote the answer.
+func (api API) problem(w http.ResponseWriter, r *http.Request, kind problemKind, detail string, args ...any) *spec.Response {
+	return api.writeProblem(w, r, kind, i18n.FromContext(r.Context()).Sprintf(detail, args...), nil)
+}
+
+// writeProblem writes an RFC 7807 body with a detail that is already
+// translated.
+func (api API) writeProblem(w http.ResponseWriter, r *http.Request, kind problemKind, detail string, fields []spec.ProblemFieldError) *spec.Response {
+	body := spec.Problem{
+		Type:     kind.typeURI(),
+		Title:    i18n.FromContext(r.Context()).Sprintf(kind.title),
+		Status:   kind.status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		Errors:   fields,
+	}
+	if id := middleware.GetReqID(r.Context()); id != "" {
+		body.RequestID = &id
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(kind.status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		api.logger.Error("Failed to write problem response", zap.Error(err))
+	}
+	return nil
+}
+
+// internalError answers with a 500. The cause is expected to be logged by the
+// caller, with whatever context it has, and is never sent to the client.
+func (api API) internalError(w http.ResponseWriter, r *http.Request) *spec.Response {
+	return api.problem(w, r, problemInternal, "Something went wrong")
+}
+
+// invalidField answers with a 422 for a single field that passed the struct
+// validation but breaks a rule that needs the database to be checked.
+func (api API) invalidField(w http.ResponseWriter, r *http.Request, field, rule, message string) *spec.Response {
+	message = i18n.FromContext(r.Context()).Sprintf(message)
+	return api.writeProblem(w, r, problemValidationFailed, message, []spec.ProblemFieldError{
+		{Field: field, Rule: rule, Message: message},
+	})
+}
+
+// validationFailed answers with a 422 listing every field rejected by the
+// validator.
+func (api API) validationFailed(w http.ResponseWriter, r *http.Request, err error) *spec.Response {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		api.logger.Error("Failed to validate request body", zap.Error(err))
+		return api.internalError(w, r)
+	}
+
+	locale := i18n.FromContext(r.Context())
+	fields := make([]spec.ProblemFieldError, len(errs))
+	for i, fe := range errs {
+		fields[i] = spec.ProblemFieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(locale, fe),
+		}
+	}
+	return api.writeProblem(w, r, problemValidationFailed, locale.Sprintf("The request body has invalid fields"), fields)
+}
+
+// ParamError answers requests whose path or query parameters could not be
+// parsed by the generated wrappers.
+func (api API) ParamError(w http.ResponseWriter, r *http.Request, err error) {
+	var param interface{ ParamName() string }
+	if errors.As(err, &param) {
+		api.problem(w, r, problemInvalidRequest, "Invalid value for parameter %q", param.ParamName())
+		return
+	}
+	api.writeProblem(w, r, problemInvalidRequest, err.Error(), nil)
+}
+
+// NotFound answers requests to routes that do not exist.
+func (api API) NotFound(w http.ResponseWriter, r *http.Request) {
+	api.problem(w, r, problemNotFound, "Route not found")
+}
+
+// MethodNotAllowed answers requests to known routes with the wrong method.
+func (api API) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	kind := problemKind{"method-not-allowed", http.StatusMethodNotAllowed, "Method not allowed"}
+	api.problem(w, r, kind, "Method %s is not allowed on this route", r.Method)
+}
+
+// jsonFieldName makes the validator report fields by their JSON name.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// fieldPath drops the struct name from the namespace of fe, so a nested field
+// reads as "emails_to_invite[0]" rather than "CreateTripRequest.emails_to_invite[0]".
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+func fieldMessage(locale i18n.Locale, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return locale.Sprintf("is required")
+	case "email":
+		return locale.Sprintf("must be a valid e-mail address")
+	case "url", "http_url":
+		return locale.Sprintf("must be a valid URL")
+	case "timezone":
+		return locale.Sprintf("must be an IANA time zone, such as America/Sao_Paulo")
+	case "iso4217":
+		return locale.Sprintf("must be an ISO 4217 currency code, such as USD")
+	case "numeric":
+		return locale.Sprintf("must be a decimal number, such as 12.50")
+	case "latitude":
+		return locale.Sprintf("must be a latitude between -90 and 90")
+	case "longitude":
+		return locale.Sprintf("must be a longitude between -180 and 180")
+	case "required_with":
+		return locale.Sprintf("is required along with %s", snakeCase(fe.Param()))
+	case "excluded_with":
+		return locale.Sprintf("cannot be given along with %s", snakeCase(fe.Param()))
+	case "oneof":
+		return locale.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min", "gte":
+		switch unitOf(fe) {
+		case "characters":
+			return locale.Sprintf("must be at least %s characters long", fe.Param())
+		case "items":
+			return locale.Sprintf("must have at least %s items", fe.Param())
+		}
+		return locale.Sprintf("must be at least %s", fe.Param())
+	case "max", "lte":
+		switch unitOf(fe) {
+		case "characters":
+			return locale.Sprintf("must be at most %s characters long", fe.Param())
+		case "items":
+			return locale.Sprintf("must have at most %s items", fe.Param())
+		}
+		return locale.Sprintf("must be at most %s", fe.Param())
+	}
+	return locale.Sprintf("failed the %q rule", fe.Tag())
+}
+
+// snakeCase turns the Go name of a field, which cross-field rules take as
+// their parameter, into its JSON name, such as DurationMinutes into
+// duration_minutes.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unitOf tells what a length rule counts for the kind of field it applies to.
+func unitOf(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return "characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	}
+	return ""
+}
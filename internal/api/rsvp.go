@@ -0,0 +1,44 @@
+package api
+
+import (
+	"planner-go/internal/ical"
+	"planner-go/internal/pgstore"
+)
+
+// rsvpTransitions lists the statuses a participant may move to from each one.
+// Participants answer as often as they like until the owner removes them or
+// the invitation expires, and only a new invitation brings them back.
+// Invitations are only expired by the reminder scheduler.
+var rsvpTransitions = map[string][]string{
+	pgstore.RSVPInvited:  {pgstore.RSVPAccepted, pgstore.RSVPDeclined, pgstore.RSVPMaybe, pgstore.RSVPRemoved},
+	pgstore.RSVPAccepted: {pgstore.RSVPDeclined, pgstore.RSVPMaybe, pgstore.RSVPRemoved},
+	pgstore.RSVPDeclined: {pgstore.RSVPAccepted, pgstore.RSVPMaybe, pgstore.RSVPRemoved},
+	pgstore.RSVPMaybe:    {pgstore.RSVPAccepted, pgstore.RSVPDeclined, pgstore.RSVPRemoved},
+	pgstore.RSVPRemoved:  {pgstore.RSVPInvited},
+	pgstore.RSVPExpired:  {pgstore.RSVPInvited, pgstore.RSVPRemoved},
+}
+
+// canTransition tells whether a participant may move from one status to
+// another.
+func canTransition(from, to string) bool {
+	for _, status := range rsvpTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// partStatus is the status an iTIP participation status stands for. Delegated
+// and unknown answers stand for none.
+func partStatus(partStat string) (string, bool) {
+	switch partStat {
+	case ical.PartStatAccepted:
+		return pgstore.RSVPAccepted, true
+	case ical.PartStatDeclined:
+		return pgstore.RSVPDeclined, true
+	case ical.PartStatTentative:
+		return pgstore.RSVPMaybe, true
+	}
+	return "", false
+}
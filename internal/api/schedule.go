@@ -0,0 +1,226 @@
+package api
+
+import (
+	"errors"
+	"planner-go/internal/api/spec"
+	"planner-go/internal/pgstore"
+	"sort"
+	"time"
+)
+
+// withinTrip reports whether an activity falls on the days of the trip, from
+// its start to its end if it has one. Days are compared instead of instants so
+// that an activity on the last day is accepted even when the trip ends_at is
+// set to midnight. The days of the activity are taken in its own time zone, if
+// it has one, and those of the trip in the time zone of the trip.
+func withinTrip(trip pgstore.Trip, activity pgstore.Activity) bool {
+	loc := trip.Zone()
+	first := trip.StartsAt.Time.In(loc).Format(time.DateOnly)
+	last := trip.EndsAt.Time.In(loc).Format(time.DateOnly)
+
+	activityLoc := activity.Zone(trip)
+	start := activity.OccursAt.Time.In(activityLoc).Format(time.DateOnly)
+	end := start
+	if activity.EndsAt.Valid {
+		end = activity.EndsAt.Time.In(activityLoc).Format(time.DateOnly)
+	}
+
+	return start >= first && end <= last
+}
+
+// localDay returns midnight in loc of the calendar day t falls on in its own
+// location.
+func localDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// groupByDay groups activities, ordered by time, by the day they happen on
+// where they happen, so a late dinner does not move to the next day of UTC.
+// Days are sorted by date, as activities in different time zones can happen in
+// an order other than that of their days.
+func groupByDay(trip pgstore.Trip, activities []pgstore.Activity) []spec.GetTripActivitiesResponseOuterArray {
+	loc := trip.Zone()
+	days := []spec.GetTripActivitiesResponseOuterArray{}
+	index := make(map[string]int)
+	for _, activity := range activities {
+		details := activityDetails(trip, activity)
+		key := details.OccursAt.Format(time.DateOnly)
+
+		i, ok := index[key]
+		if !ok {
+			i = len(days)
+			index[key] = i
+			days = append(days, spec.GetTripActivitiesResponseOuterArray{Date: localDay(details.OccursAt, loc)})
+		}
+		days[i].Activities = append(days[i].Activities, details)
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days
+}
+
+// fillDays adds the days from first to last, both midnights, which have no
+// activities to days, keeping them sorted.
+func fillDays(days []spec.GetTripActivitiesResponseOuterArray, first, last time.Time) []spec.GetTripActivitiesResponseOuterArray {
+	seen := make(map[string]bool, len(days))
+	for _, day := range days {
+		seen[day.Date.Format(time.DateOnly)] = true
+	}
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !seen[day.Format(time.DateOnly)] {
+			days = append(days, spec.GetTripActivitiesResponseOuterArray{
+				Date:       day,
+				Activities: []spec.GetTripActivitiesResponseInnerArray{},
+			})
+		}
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days
+}
+
+// overlapping reports whether two activities take some of the same time.
+// Activities without an end take an instant, which overlaps an activity that
+// starts at the same time or runs across it. One that ends as the other starts
+// does not overlap it.
+func overlapping(a, b pgstore.Activity) bool {
+	if a.OccursAt.Time.Equal(b.OccursAt.Time) {
+		return true
+	}
+	return a.OccursAt.Time.Before(activityEnd(b)) && b.OccursAt.Time.Before(activityEnd(a))
+}
+
+func activityEnd(a pgstore.Activity) time.Time {
+	if !a.EndsAt.Valid {
+		return a.OccursAt.Time
+	}
+	return a.EndsAt.Time
+}
+
+// overlapsWith returns the activities, other than itself, that activity
+// overlaps.
+func overlapsWith(activity pgstore.Activity, activities []pgstore.Activity) []pgstore.Activity {
+	var found []pgstore.Activity
+	for _, other := range activities {
+		if other.ID != activity.ID && overlapping(activity, other) {
+			found = append(found, other)
+		}
+	}
+	return found
+}
+
+// overlaps returns every pair of overlapping activities. Activities must be
+// ordered by start, so the search for the ones overlapping an activity stops
+// at the first that starts after it ends.
+func overlaps(activities []pgstore.Activity) [][2]pgstore.Activity {
+	var pairs [][2]pgstore.Activity
+	for i, a := range activities {
+		end := activityEnd(a)
+		for _, b := range activities[i+1:] {
+			if b.OccursAt.Time.After(end) {
+				break
+			}
+			if overlapping(a, b) {
+				pairs = append(pairs, [2]pgstore.Activity{a, b})
+			}
+		}
+	}
+	return pairs
+}
+
+// outOfRange returns the activities that do not fall on the days of the trip.
+func outOfRange(trip pgstore.Trip, activities []pgstore.Activity) []pgstore.Activity {
+	var found []pgstore.Activity
+	for _, activity := range activities {
+		if !withinTrip(trip, activity) {
+			found = append(found, activity)
+		}
+	}
+	return found
+}
+
+// orphaned returns the activities that fall on the days of trip but not on
+// those of updated, the same trip with new dates or time zone. Activities that
+// were already out of range are not counted, so they do not block every later
+// update of the trip.
+func orphaned(trip, updated pgstore.Trip, activities []pgstore.Activity) []pgstore.Activity {
+	var found []pgstore.Activity
+	for _, activity := range activities {
+		if withinTrip(trip, activity) && !withinTrip(updated, activity) {
+			found = append(found, activity)
+		}
+	}
+	return found
+}
+
+var errInvalidOverlapPolicy = errors.New("api: invalid on_overlap")
+
+// errOverlapRejected stops the write of an activity that overlaps others when
+// the on_overlap parameter rejects it.
+var errOverlapRejected = errors.New("api: activity overlaps others")
+
+// errOutsideTrip stops the write of an activity that does not fall on the days
+// of its trip.
+var errOutsideTrip = errors.New("api: activity is outside the trip")
+
+// errActivitiesOrphaned stops an update of a trip that would leave some of its
+// activities outside its days.
+var errActivitiesOrphaned = errors.New("api: activities left outside the trip")
+
+// checkActivity returns the check that the store runs, with the trip locked,
+// before it writes activity. It fails if activity does not fall on the days of
+// the trip, and sets overlapping to the activities it overlaps, failing too if
+// reject is set and there are any.
+func checkActivity(activity pgstore.Activity, reject bool, overlapping *[]pgstore.Activity) func(pgstore.Trip, []pgstore.Activity) error {
+	return func(trip pgstore.Trip, activities []pgstore.Activity) error {
+		if !withinTrip(trip, activity) {
+			return errOutsideTrip
+		}
+
+		*overlapping = overlapsWith(activity, activities)
+		if reject && len(*overlapping) > 0 {
+			return errOverlapRejected
+		}
+		return nil
+	}
+}
+
+// checkTripDates returns the check that the store runs, with the trip locked,
+// before it updates the trip with params. It sets orphans to the activities
+// the new dates or time zone leave outside the trip, and fails if there are
+// any.
+func checkTripDates(params pgstore.UpdateTripParams, orphans *[]pgstore.Activity) func(pgstore.Trip, []pgstore.Activity) error {
+	return func(trip pgstore.Trip, activities []pgstore.Activity) error {
+		updated := trip
+		updated.StartsAt = params.StartsAt
+		updated.EndsAt = params.EndsAt
+		updated.TimeZone = params.TimeZone
+
+		*orphans = orphaned(trip, updated, activities)
+		if len(*orphans) > 0 {
+			return errActivitiesOrphaned
+		}
+		return nil
+	}
+}
+
+// rejectOverlaps tells from the on_overlap parameter whether an activity that
+// overlaps others must be rejected, which it is not by default.
+func rejectOverlaps(onOverlap *string) (bool, error) {
+	if onOverlap == nil {
+		return false, nil
+	}
+	switch *onOverlap {
+	case "warn":
+		return false, nil
+	case "reject":
+		return true, nil
+	}
+	return false, errInvalidOverlapPolicy
+}
@@ -0,0 +1,357 @@
+package api
+
+import (
+	"errors"
+	"planner-go/internal/api/spec"
+	"planner-go/internal/pgstore"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var scheduleBase = time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+// span returns an activity starting minutes after scheduleBase and lasting
+// length minutes, or an instant if length is 0.
+func span(minutes, length int) pgstore.Activity {
+	activity := pgstore.Activity{
+		ID:       uuid.New(),
+		OccursAt: pgtype.Timestamptz{Time: scheduleBase.Add(time.Duration(minutes) * time.Minute), Valid: true},
+	}
+	if length > 0 {
+		activity.EndsAt = pgtype.Timestamptz{Time: activity.OccursAt.Time.Add(time.Duration(length) * time.Minute), Valid: true}
+	}
+	return activity
+}
+
+func TestOverlapping(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b pgstore.Activity
+		want bool
+	}{
+		{"same span", span(0, 60), span(0, 60), true},
+		{"same start", span(0, 60), span(0, 30), true},
+		{"partial", span(0, 60), span(30, 60), true},
+		{"nested", span(0, 120), span(30, 30), true},
+		{"back to back", span(0, 60), span(60, 60), false},
+		{"apart", span(0, 60), span(90, 60), false},
+		{"instant at start", span(0, 60), span(0, 0), true},
+		{"instant inside", span(0, 60), span(30, 0), true},
+		{"instant at end", span(0, 60), span(60, 0), false},
+		{"instant before", span(30, 60), span(0, 0), false},
+		{"same instant", span(0, 0), span(0, 0), true},
+		{"instants apart", span(0, 0), span(1, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlapping(tt.a, tt.b); got != tt.want {
+				t.Errorf("overlapping(a, b) = %v, want %v", got, tt.want)
+			}
+			if got := overlapping(tt.b, tt.a); got != tt.want {
+				t.Errorf("overlapping(b, a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		name       string
+		activities []pgstore.Activity
+		want       [][2]int
+	}{
+		{"none", nil, nil},
+		{"back to back", []pgstore.Activity{span(0, 60), span(60, 60), span(120, 60)}, nil},
+		{"chain", []pgstore.Activity{span(0, 60), span(30, 60), span(60, 60)}, [][2]int{{0, 1}, {1, 2}}},
+		{"long one", []pgstore.Activity{span(0, 180), span(30, 30), span(120, 0)}, [][2]int{{0, 1}, {0, 2}}},
+		{"instant at boundary", []pgstore.Activity{span(0, 60), span(60, 0), span(60, 30)}, [][2]int{{1, 2}}},
+		{"past a gap", []pgstore.Activity{span(0, 30), span(60, 0), span(90, 30)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := overlaps(tt.activities)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d pairs, want %d", len(got), len(tt.want))
+			}
+			for i, pair := range tt.want {
+				if got[i][0].ID != tt.activities[pair[0]].ID || got[i][1].ID != tt.activities[pair[1]].ID {
+					t.Errorf("pair %d: got other activities than %v", i, pair)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckActivity(t *testing.T) {
+	trip := pgstore.Trip{
+		TimeZone: "UTC",
+		StartsAt: pgtype.Timestamptz{Time: scheduleBase.Truncate(24 * time.Hour), Valid: true},
+		EndsAt:   pgtype.Timestamptz{Time: scheduleBase.Truncate(24 * time.Hour), Valid: true},
+	}
+	existing := []pgstore.Activity{span(0, 60), span(60, 60)}
+	moved := existing[1]
+	moved.OccursAt.Time = scheduleBase.Add(30 * time.Minute)
+	moved.EndsAt.Time = scheduleBase.Add(90 * time.Minute)
+
+	tests := []struct {
+		name     string
+		activity pgstore.Activity
+		reject   bool
+		want     int
+		wantErr  error
+	}{
+		{"free", span(120, 30), true, 0, nil},
+		{"warn", span(30, 60), false, 2, nil},
+		{"reject", span(30, 60), true, 2, errOverlapRejected},
+		{"back to back", span(120, 0), true, 0, nil},
+		{"itself", existing[1], true, 0, nil},
+		{"itself moved", moved, true, 1, errOverlapRejected},
+		{"itself moved with warn", moved, false, 1, nil},
+		{"outside the trip", span(24*60, 30), false, 0, errOutsideTrip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var overlapping []pgstore.Activity
+			err := checkActivity(tt.activity, tt.reject, &overlapping)(trip, existing)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if len(overlapping) != tt.want {
+				t.Errorf("got %d overlapping, want %d", len(overlapping), tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTripDates(t *testing.T) {
+	trip := tripOn(t, "America/Sao_Paulo", "2024-07-01", "2024-07-05")
+	activities := []pgstore.Activity{
+		at(t, "America/Sao_Paulo", "2024-07-02 10:00"),
+		// 22:00 of the last day where the trip is, with no time zone of its own
+		at(t, "", "2024-07-06 01:00"),
+		// already outside, so it does not block the update
+		at(t, "America/Sao_Paulo", "2024-07-09 10:00"),
+	}
+	params := func(zone, first, last string) pgstore.UpdateTripParams {
+		updated := tripOn(t, zone, first, last)
+		return pgstore.UpdateTripParams{StartsAt: updated.StartsAt, EndsAt: updated.EndsAt, TimeZone: zone, ID: trip.ID}
+	}
+
+	tests := []struct {
+		name    string
+		params  pgstore.UpdateTripParams
+		want    int
+		wantErr error
+	}{
+		{"same days", params("America/Sao_Paulo", "2024-07-01", "2024-07-05"), 0, nil},
+		{"longer", params("America/Sao_Paulo", "2024-06-30", "2024-07-06"), 0, nil},
+		{"shorter", params("America/Sao_Paulo", "2024-07-01", "2024-07-04"), 1, errActivitiesOrphaned},
+		{"later start", params("America/Sao_Paulo", "2024-07-03", "2024-07-05"), 1, errActivitiesOrphaned},
+		{"zone moves the last evening", params("Asia/Tokyo", "2024-07-01", "2024-07-05"), 1, errActivitiesOrphaned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var orphans []pgstore.Activity
+			err := checkTripDates(tt.params, &orphans)(trip, activities)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if len(orphans) != tt.want {
+				t.Errorf("got %d orphans, want %d", len(orphans), tt.want)
+			}
+		})
+	}
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// tripOn returns a trip in zone from the midnight of first to that of last,
+// both dates written as 2006-01-02.
+func tripOn(t *testing.T, zone, first, last string) pgstore.Trip {
+	t.Helper()
+	loc := mustLoad(t, zone)
+	parse := func(day string) pgtype.Timestamptz {
+		at, err := time.ParseInLocation(time.DateOnly, day, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pgtype.Timestamptz{Time: at, Valid: true}
+	}
+	return pgstore.Trip{ID: uuid.New(), TimeZone: zone, StartsAt: parse(first), EndsAt: parse(last)}
+}
+
+// at returns an activity at the time written as 2006-01-02 15:04 in zone,
+// which is also its own time zone unless it is empty.
+func at(t *testing.T, zone, when string) pgstore.Activity {
+	t.Helper()
+	loc := time.UTC
+	if zone != "" {
+		loc = mustLoad(t, zone)
+	}
+	occursAt, err := time.ParseInLocation("2006-01-02 15:04", when, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pgstore.Activity{
+		ID:       uuid.New(),
+		OccursAt: pgtype.Timestamptz{Time: occursAt, Valid: true},
+		TimeZone: pgtype.Text{String: zone, Valid: zone != ""},
+	}
+}
+
+func dates(days []spec.GetTripActivitiesResponseOuterArray) []string {
+	found := make([]string, len(days))
+	for i, day := range days {
+		found[i] = day.Date.Format(time.DateOnly)
+	}
+	return found
+}
+
+func equalDates(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWithinTrip(t *testing.T) {
+	trip := tripOn(t, "America/Sao_Paulo", "2024-07-01", "2024-07-05")
+
+	tests := []struct {
+		name     string
+		activity pgstore.Activity
+		want     bool
+	}{
+		{"first day", at(t, "America/Sao_Paulo", "2024-07-01 00:00"), true},
+		{"last day after midnight end", at(t, "America/Sao_Paulo", "2024-07-05 22:00"), true},
+		{"day before", at(t, "America/Sao_Paulo", "2024-06-30 23:59"), false},
+		{"day after", at(t, "America/Sao_Paulo", "2024-07-06 00:00"), false},
+		{"own zone on the last day", at(t, "Asia/Tokyo", "2024-07-05 23:00"), true},
+		{"own zone the day after", at(t, "Asia/Tokyo", "2024-07-06 09:00"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withinTrip(trip, tt.activity); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupByDay(t *testing.T) {
+	trip := tripOn(t, "America/Sao_Paulo", "2024-07-01", "2024-07-05")
+
+	tests := []struct {
+		name       string
+		activities []pgstore.Activity
+		want       []string
+		sizes      []int
+	}{
+		{"none", nil, []string{}, nil},
+		{
+			"late dinner stays on its day",
+			[]pgstore.Activity{at(t, "America/Sao_Paulo", "2024-07-01 22:30"), at(t, "America/Sao_Paulo", "2024-07-01 23:30")},
+			[]string{"2024-07-01"},
+			[]int{2},
+		},
+		{
+			"days in other zones sorted by date",
+			// ordered by time, the flight lands on a later day in Tokyo than
+			// the walk in Sao Paulo that follows it
+			[]pgstore.Activity{at(t, "Asia/Tokyo", "2024-07-03 08:00"), at(t, "America/Sao_Paulo", "2024-07-02 21:00")},
+			[]string{"2024-07-02", "2024-07-03"},
+			[]int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := groupByDay(trip, tt.activities)
+			if got := dates(days); !equalDates(got, tt.want) {
+				t.Fatalf("got days %v, want %v", got, tt.want)
+			}
+			for i, day := range days {
+				if len(day.Activities) != tt.sizes[i] {
+					t.Errorf("day %s: got %d activities, want %d", tt.want[i], len(day.Activities), tt.sizes[i])
+				}
+				if day.Date.Location().String() != trip.TimeZone || day.Date.Hour() != 0 {
+					t.Errorf("day %s: got date %v, want a midnight of the trip", tt.want[i], day.Date)
+				}
+			}
+		})
+	}
+}
+
+func TestFillDays(t *testing.T) {
+	sao := mustLoad(t, "America/Sao_Paulo")
+	berlin := mustLoad(t, "Europe/Berlin")
+	day := func(loc *time.Location, date string) time.Time {
+		d, err := time.ParseInLocation(time.DateOnly, date, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	withDays := func(loc *time.Location, dates ...string) []spec.GetTripActivitiesResponseOuterArray {
+		days := []spec.GetTripActivitiesResponseOuterArray{}
+		for _, date := range dates {
+			days = append(days, spec.GetTripActivitiesResponseOuterArray{
+				Date:       day(loc, date),
+				Activities: []spec.GetTripActivitiesResponseInnerArray{{Title: date}},
+			})
+		}
+		return days
+	}
+
+	tests := []struct {
+		name        string
+		days        []spec.GetTripActivitiesResponseOuterArray
+		first, last time.Time
+		want        []string
+	}{
+		{"empty trip", withDays(sao), day(sao, "2024-07-01"), day(sao, "2024-07-03"), []string{"2024-07-01", "2024-07-02", "2024-07-03"}},
+		{"gaps", withDays(sao, "2024-07-02"), day(sao, "2024-07-01"), day(sao, "2024-07-03"), []string{"2024-07-01", "2024-07-02", "2024-07-03"}},
+		{"full", withDays(sao, "2024-07-01", "2024-07-02"), day(sao, "2024-07-01"), day(sao, "2024-07-02"), []string{"2024-07-01", "2024-07-02"}},
+		{"single day", withDays(sao), day(sao, "2024-07-01"), day(sao, "2024-07-01"), []string{"2024-07-01"}},
+		{"window past the last day", withDays(sao, "2024-07-01"), day(sao, "2024-07-03"), day(sao, "2024-07-02"), []string{"2024-07-01"}},
+		{"days outside the window kept", withDays(sao, "2024-06-30"), day(sao, "2024-07-01"), day(sao, "2024-07-01"), []string{"2024-06-30", "2024-07-01"}},
+		{"daylight saving", withDays(berlin), day(berlin, "2024-03-30"), day(berlin, "2024-04-01"), []string{"2024-03-30", "2024-03-31", "2024-04-01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := fillDays(tt.days, tt.first, tt.last)
+			if got := dates(days); !equalDates(got, tt.want) {
+				t.Fatalf("got days %v, want %v", got, tt.want)
+			}
+			for _, d := range days {
+				if d.Activities == nil {
+					t.Errorf("day %s: got nil activities, want an empty list", d.Date.Format(time.DateOnly))
+				}
+				if d.Date.Hour() != 0 {
+					t.Errorf("day %s: got %v, want midnight", d.Date.Format(time.DateOnly), d.Date)
+				}
+			}
+		})
+	}
+}
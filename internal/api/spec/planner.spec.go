@@ -6,6 +6,7 @@ package spec
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -23,15 +24,149 @@ import (
 	"github.com/go-chi/render"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// ActivityConflictsResponse defines model for ActivityConflictsResponse.
+type ActivityConflictsResponse struct {
+	// Activities that fall outside the days of the trip.
+	OutOfRange []GetTripActivitiesResponseInnerArray `json:"out_of_range"`
+
+	// Pairs of activities that overlap, ordered by the start of the first one.
+	Overlaps []ActivityOverlap `json:"overlaps"`
+}
+
+// ActivityOverlap defines model for ActivityOverlap.
+type ActivityOverlap struct {
+	Activities []GetTripActivitiesResponseInnerArray `json:"activities"`
+}
+
+// BudgetCategory defines model for BudgetCategory.
+type BudgetCategory struct {
+	// Amount planned for the category, as a decimal number in the currency of the budget.
+	Amount string `json:"amount" validate:"required,numeric"`
+
+	// One of the activity categories.
+	Category string `json:"category" validate:"required,oneof=food transport sightseeing lodging leisure shopping other"`
+}
+
+// BudgetCategoryReport defines model for BudgetCategoryReport.
+type BudgetCategoryReport struct {
+	// Expenses of the activities of the category, converted to the currency of the budget.
+	Actual   string `json:"actual"`
+	Category string `json:"category"`
+
+	// Amount planned for the category, null when it has none.
+	Planned *string `json:"planned"`
+
+	// Planned minus actual, negative when over budget.
+	Remaining *string `json:"remaining"`
+}
+
+// BulkInviteRequest defines model for BulkInviteRequest.
+type BulkInviteRequest struct {
+	// Addresses to invite, either bare or as Name <address>.
+	Emails []string `json:"emails" validate:"required,min=1,max=500"`
+
+	// One of en, pt-BR or es. Defaults to the locale of the trip owner.
+	Locale *string `json:"locale,omitempty" validate:"omitempty,oneof=en pt-BR es"`
+}
+
+// BulkInviteResponse defines model for BulkInviteResponse.
+type BulkInviteResponse struct {
+	// Invitation e-mails queued, one per invited address.
+	QueuedEmails int                `json:"queued_emails"`
+	Results      []BulkInviteResult `json:"results"`
+}
+
+// BulkInviteResult defines model for BulkInviteResult.
+type BulkInviteResult struct {
+	// The normalized address, null when it is invalid.
+	Email *openapi_types.Email `json:"email"`
+
+	// The address as it was sent.
+	Input string `json:"input"`
+
+	// One of invited, already_present or invalid.
+	Outcome string `json:"outcome"`
+
+	// Why the address is invalid.
+	Reason *string `json:"reason"`
+}
+
+// CalendarSubscriptionResponse defines model for CalendarSubscriptionResponse.
+type CalendarSubscriptionResponse struct {
+	// Address calendar apps can subscribe to. It carries the token, so keep it private.
+	URL string `json:"url"`
+}
+
+// ConfirmParticipantRequest defines model for ConfirmParticipantRequest.
+type ConfirmParticipantRequest struct {
+	// Name to be shown with. Keeps the current one when left out.
+	Name *string `json:"name,omitempty" validate:"omitempty,max=255"`
+}
+
 // CreateActivityRequest defines model for CreateActivityRequest.
 type CreateActivityRequest struct {
-	OccursAt time.Time `json:"occurs_at" validate:"required"`
-	Title    string    `json:"title" validate:"required"`
+	// One of food, transport, sightseeing, lodging, leisure, shopping or other. Defaults to other.
+	Category *string `json:"category,omitempty" validate:"omitempty,oneof=food transport sightseeing lodging leisure shopping other"`
+
+	// How long the activity lasts, instead of ends_at.
+	DurationMinutes *int `json:"duration_minutes,omitempty" validate:"omitempty,min=1,max=44640"`
+
+	// When the activity ends. Cannot be given along with duration_minutes.
+	EndsAt *time.Time `json:"ends_at,omitempty" validate:"omitempty,excluded_with=DurationMinutes"`
+
+	// Latitude of the location. Must be given along with longitude.
+	Latitude *float64 `json:"latitude,omitempty" validate:"required_with=Longitude,omitempty,latitude"`
+
+	// Where the activity happens, such as an address or the name of a place.
+	Location *string `json:"location,omitempty" validate:"omitempty,max=255"`
+
+	// Longitude of the location. Must be given along with latitude.
+	Longitude *float64  `json:"longitude,omitempty" validate:"required_with=Latitude,omitempty,longitude"`
+	Notes     *string   `json:"notes,omitempty" validate:"omitempty,max=2000"`
+	OccursAt  time.Time `json:"occurs_at" validate:"required"`
+
+	// IANA time zone of the activity, for trips across several zones. Defaults to that of the trip.
+	TimeZone *string `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+	Title    string  `json:"title" validate:"required"`
 }
 
 // CreateActivityResponse defines model for CreateActivityResponse.
 type CreateActivityResponse struct {
 	ActivityID string `json:"activityId"`
+
+	// Activities the new one overlaps, when on_overlap is warn.
+	OverlappingActivities []GetTripActivitiesResponseInnerArray `json:"overlapping_activities"`
+}
+
+// CreateExpenseRequest defines model for CreateExpenseRequest.
+type CreateExpenseRequest struct {
+	// Activity the expense belongs to, if any.
+	ActivityID *string `json:"activity_id,omitempty" validate:"omitempty,uuid"`
+
+	// Amount paid, as a decimal number in the currency, with at most as many decimal places as it has.
+	Amount string `json:"amount" validate:"required,numeric"`
+
+	// ISO 4217 code of the currency.
+	Currency    string `json:"currency" validate:"required,iso4217"`
+	Description string `json:"description" validate:"required,max=255"`
+
+	// Participant who paid.
+	PayerID string `json:"payer_id" validate:"required,uuid"`
+
+	// One of equal, shares or exact.
+	Split string `json:"split" validate:"required,oneof=equal shares exact"`
+
+	// Participants who share the expense. The equal split may leave it empty to share it among every participant of the trip.
+	Splits []ExpenseSplit `json:"splits,omitempty" validate:"max=500,dive"`
+}
+
+// CreateExpenseResponse defines model for CreateExpenseResponse.
+type CreateExpenseResponse struct {
+	ExpenseID string `json:"expense_id"`
 }
 
 // CreateLinkRequest defines model for CreateLinkRequest.
@@ -45,14 +180,27 @@ type CreateLinkResponse struct {
 	LinkID string `json:"linkId"`
 }
 
+// CreateSessionRequest defines model for CreateSessionRequest.
+type CreateSessionRequest struct {
+	Email    openapi_types.Email `json:"email" validate:"required,email"`
+	Password string              `json:"password" validate:"required"`
+}
+
+// CreateSessionResponse defines model for CreateSessionResponse.
+type CreateSessionResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Token     string    `json:"token"`
+}
+
 // CreateTripRequest defines model for CreateTripRequest.
 type CreateTripRequest struct {
 	Destination    string                `json:"destination" validate:"required,min=4"`
 	EmailsToInvite []openapi_types.Email `json:"emails_to_invite" validate:"required,dive,email"`
 	EndsAt         time.Time             `json:"ends_at" validate:"required"`
-	OwnerEmail     openapi_types.Email   `json:"owner_email" validate:"required,email"`
-	OwnerName      string                `json:"owner_name" validate:"required"`
 	StartsAt       time.Time             `json:"starts_at" validate:"required"`
+
+	// IANA time zone of the trip, such as America/Sao_Paulo. Defaults to UTC.
+	TimeZone *string `json:"time_zone,omitempty" validate:"omitempty,timezone"`
 }
 
 // CreateTripResponse defines model for CreateTripResponse.
@@ -60,14 +208,102 @@ type CreateTripResponse struct {
 	TripID string `json:"tripId"`
 }
 
-// Bad request
-type Error struct {
-	Message string `json:"message"`
+// CreateUserRequest defines model for CreateUserRequest.
+type CreateUserRequest struct {
+	Email openapi_types.Email `json:"email" validate:"required,email"`
+
+	// One of en, pt-BR or es. Defaults to the Accept-Language of the request.
+	Locale   *string `json:"locale,omitempty" validate:"omitempty,oneof=en pt-BR es"`
+	Name     string  `json:"name" validate:"required"`
+	Password string  `json:"password" validate:"required,min=8,max=72"`
+}
+
+// CreateUserResponse defines model for CreateUserResponse.
+type CreateUserResponse struct {
+	UserID string `json:"userId"`
+}
+
+// CurrencyBalance defines model for CurrencyBalance.
+type CurrencyBalance struct {
+	Currency     string               `json:"currency"`
+	Participants []ParticipantBalance `json:"participants"`
+
+	// Payments that bring every balance to zero.
+	Transfers []SettleUpTransfer `json:"transfers"`
+}
+
+// DigestSubscriptionResponse defines model for DigestSubscriptionResponse.
+type DigestSubscriptionResponse struct {
+	// Whether the participant receives the daily digest and the notice before the trip starts.
+	Enabled bool `json:"enabled"`
+}
+
+// ExpenseShare defines model for ExpenseShare.
+type ExpenseShare struct {
+	Amount        string `json:"amount"`
+	ParticipantID string `json:"participant_id"`
+	Shares        *int   `json:"shares"`
+}
+
+// ExpenseSplit defines model for ExpenseSplit.
+type ExpenseSplit struct {
+	// Amount owed by the participant, as a decimal number in the currency of the expense. Required by the exact split.
+	Amount        *string `json:"amount,omitempty" validate:"omitempty,numeric"`
+	ParticipantID string  `json:"participant_id" validate:"required,uuid"`
+
+	// Shares of the participant. Required by the shares split.
+	Shares *int `json:"shares,omitempty" validate:"omitempty,min=1,max=1000"`
+}
+
+// GetBalancesResponse defines model for GetBalancesResponse.
+type GetBalancesResponse struct {
+	// Balances for each currency of the expenses, which are never converted.
+	Balances []CurrencyBalance `json:"balances"`
+}
+
+// GetBudgetResponse defines model for GetBudgetResponse.
+type GetBudgetResponse struct {
+	// Every expense of the trip, converted to the currency of the budget.
+	Actual string `json:"actual"`
+
+	// Categories with a budget or with expenses. Expenses without an activity count as other.
+	Categories []BudgetCategoryReport `json:"categories"`
+	Currency   string                 `json:"currency"`
+
+	// Amount planned for the whole trip, null when the budget has no total nor categories.
+	Planned *string `json:"planned"`
+
+	// Planned minus actual, negative when over budget.
+	Remaining *string `json:"remaining"`
+}
+
+// GetExpensesResponse defines model for GetExpensesResponse.
+type GetExpensesResponse struct {
+	Expenses []GetExpensesResponseArray `json:"expenses"`
+
+	// Cursor of the next page, null on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
+
+// GetExpensesResponseArray defines model for GetExpensesResponseArray.
+type GetExpensesResponseArray struct {
+	ActivityID  *string        `json:"activity_id"`
+	Amount      string         `json:"amount"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Currency    string         `json:"currency"`
+	Description string         `json:"description"`
+	ID          string         `json:"id"`
+	PayerID     string         `json:"payer_id"`
+	Shares      []ExpenseShare `json:"shares"`
+	Split       string         `json:"split"`
 }
 
 // GetLinksResponse defines model for GetLinksResponse.
 type GetLinksResponse struct {
 	Links []GetLinksResponseArray `json:"links"`
+
+	// Cursor of the next page, null on the last page.
+	NextCursor *string `json:"next_cursor"`
 }
 
 // GetLinksResponseArray defines model for GetLinksResponseArray.
@@ -80,19 +316,34 @@ type GetLinksResponseArray struct {
 // GetTripActivitiesResponse defines model for GetTripActivitiesResponse.
 type GetTripActivitiesResponse struct {
 	Activities []GetTripActivitiesResponseOuterArray `json:"activities"`
+
+	// Cursor of the next page, null on the last page.
+	NextCursor *string `json:"next_cursor"`
 }
 
 // GetTripActivitiesResponseInnerArray defines model for GetTripActivitiesResponseInnerArray.
 type GetTripActivitiesResponseInnerArray struct {
-	ID       string    `json:"id"`
-	OccursAt time.Time `json:"occurs_at"`
-	Title    string    `json:"title"`
+	Category        string     `json:"category"`
+	DurationMinutes *int       `json:"duration_minutes"`
+	EndsAt          *time.Time `json:"ends_at"`
+	ID              string     `json:"id"`
+	Latitude        *float64   `json:"latitude"`
+	Location        *string    `json:"location"`
+	Longitude       *float64   `json:"longitude"`
+	Notes           string     `json:"notes"`
+	OccursAt        time.Time  `json:"occurs_at"`
+
+	// IANA time zone of the activity, null when it follows that of the trip.
+	TimeZone *string `json:"time_zone"`
+	Title    string  `json:"title"`
 }
 
 // GetTripActivitiesResponseOuterArray defines model for GetTripActivitiesResponseOuterArray.
 type GetTripActivitiesResponseOuterArray struct {
 	Activities []GetTripActivitiesResponseInnerArray `json:"activities"`
-	Date       time.Time                             `json:"date"`
+
+	// Midnight of the day, in the time zone of the trip. Activities fall on the day they happen in their own time zone.
+	Date time.Time `json:"date"`
 }
 
 // GetTripDetailsResponse defines model for GetTripDetailsResponse.
@@ -107,24 +358,186 @@ type GetTripDetailsResponseTripObj struct {
 	ID          string    `json:"id"`
 	IsConfirmed bool      `json:"is_confirmed"`
 	StartsAt    time.Time `json:"starts_at"`
+
+	// IANA time zone of the trip, in which starts_at and ends_at are written.
+	TimeZone string `json:"time_zone"`
 }
 
 // GetTripParticipantsResponse defines model for GetTripParticipantsResponse.
 type GetTripParticipantsResponse struct {
+	// Cursor of the next page, null on the last page.
+	NextCursor   *string                            `json:"next_cursor"`
 	Participants []GetTripParticipantsResponseArray `json:"participants"`
 }
 
 // GetTripParticipantsResponseArray defines model for GetTripParticipantsResponseArray.
 type GetTripParticipantsResponseArray struct {
-	Email       openapi_types.Email `json:"email"`
-	ID          string              `json:"id"`
-	IsConfirmed bool                `json:"is_confirmed"`
-	Name        *string             `json:"name"`
+	Email     openapi_types.Email `json:"email"`
+	ID        string              `json:"id"`
+	InvitedAt time.Time           `json:"invited_at"`
+
+	// Locale the participant is e-mailed in.
+	Locale string `json:"locale"`
+
+	// Name the participant is shown with, null when none was given.
+	Name *string `json:"name"`
+
+	// When the participant was removed from the trip.
+	RemovedAt *time.Time `json:"removed_at"`
+
+	// When the participant last answered the invitation.
+	RespondedAt *time.Time `json:"responded_at"`
+
+	// One of owner, editor or viewer.
+	Role string `json:"role"`
+
+	// One of invited, accepted, declined, maybe or removed.
+	Status string `json:"status"`
+}
+
+// GetTripsResponse defines model for GetTripsResponse.
+type GetTripsResponse struct {
+	// Cursor of the next page, null on the last page.
+	NextCursor *string                         `json:"next_cursor"`
+	Trips      []GetTripDetailsResponseTripObj `json:"trips"`
 }
 
 // InviteParticipantRequest defines model for InviteParticipantRequest.
 type InviteParticipantRequest struct {
 	Email openapi_types.Email `json:"email" validate:"required,email"`
+
+	// One of en, pt-BR or es. Defaults to the locale of the trip owner.
+	Locale *string `json:"locale,omitempty" validate:"omitempty,oneof=en pt-BR es"`
+
+	// Name the participant is shown with, until they give their own.
+	Name *string `json:"name,omitempty" validate:"omitempty,max=255"`
+}
+
+// ParticipantBalance defines model for ParticipantBalance.
+type ParticipantBalance struct {
+	Email openapi_types.Email `json:"email"`
+
+	// Paid minus owed. Positive when the participant is owed money.
+	Net string `json:"net"`
+
+	// Total of the participant shares.
+	Owed string `json:"owed"`
+
+	// Total paid by the participant.
+	Paid          string `json:"paid"`
+	ParticipantID string `json:"participant_id"`
+}
+
+// An RFC 7807 problem detail.
+type Problem struct {
+	// Explanation specific to this occurrence.
+	Detail string `json:"detail"`
+
+	// Fields that failed validation.
+	Errors []ProblemFieldError `json:"errors,omitempty"`
+
+	// Path of the request that failed.
+	Instance string `json:"instance"`
+
+	// ID of the request, to be quoted when reporting the problem.
+	RequestID *string `json:"request_id,omitempty"`
+
+	// HTTP status code of the response.
+	Status int `json:"status"`
+
+	// Short summary of the kind of problem.
+	Title string `json:"title"`
+
+	// Stable URI identifying the kind of problem, such as urn:planner:problem:not-found.
+	Type string `json:"type"`
+}
+
+// ProblemFieldError defines model for ProblemFieldError.
+type ProblemFieldError struct {
+	// JSON path of the field in the request body.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+
+	// Validation rule the field broke.
+	Rule string `json:"rule"`
+}
+
+// RespondInvitationRequest defines model for RespondInvitationRequest.
+type RespondInvitationRequest struct {
+	// Name to be shown with. Keeps the current one when left out.
+	Name *string `json:"name,omitempty" validate:"omitempty,max=255"`
+
+	// One of accepted, declined or maybe.
+	Status string `json:"status" validate:"required,oneof=accepted declined maybe"`
+}
+
+// SettleUpTransfer defines model for SettleUpTransfer.
+type SettleUpTransfer struct {
+	Amount            string `json:"amount"`
+	FromParticipantID string `json:"from_participant_id"`
+	ToParticipantID   string `json:"to_participant_id"`
+}
+
+// TransferOwnershipRequest defines model for TransferOwnershipRequest.
+type TransferOwnershipRequest struct {
+	// Participant who becomes the owner. They must have accepted the invitation and have an account.
+	ParticipantID string `json:"participant_id" validate:"required,uuid"`
+}
+
+// UpdateActivityRequest defines model for UpdateActivityRequest.
+type UpdateActivityRequest struct {
+	// One of food, transport, sightseeing, lodging, leisure, shopping or other. Defaults to other.
+	Category *string `json:"category,omitempty" validate:"omitempty,oneof=food transport sightseeing lodging leisure shopping other"`
+
+	// How long the activity lasts, instead of ends_at.
+	DurationMinutes *int `json:"duration_minutes,omitempty" validate:"omitempty,min=1,max=44640"`
+
+	// When the activity ends. Cannot be given along with duration_minutes.
+	EndsAt *time.Time `json:"ends_at,omitempty" validate:"omitempty,excluded_with=DurationMinutes"`
+
+	// Latitude of the location. Must be given along with longitude.
+	Latitude *float64 `json:"latitude,omitempty" validate:"required_with=Longitude,omitempty,latitude"`
+
+	// Where the activity happens, such as an address or the name of a place.
+	Location *string `json:"location,omitempty" validate:"omitempty,max=255"`
+
+	// Longitude of the location. Must be given along with latitude.
+	Longitude *float64  `json:"longitude,omitempty" validate:"required_with=Latitude,omitempty,longitude"`
+	Notes     *string   `json:"notes,omitempty" validate:"omitempty,max=2000"`
+	OccursAt  time.Time `json:"occurs_at" validate:"required"`
+
+	// IANA time zone of the activity. The activity follows the time zone of the trip when omitted.
+	TimeZone *string `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+	Title    string  `json:"title" validate:"required"`
+}
+
+// UpdateActivityResponse defines model for UpdateActivityResponse.
+type UpdateActivityResponse struct {
+	// Activities the updated one overlaps, when on_overlap is warn.
+	OverlappingActivities []GetTripActivitiesResponseInnerArray `json:"overlapping_activities"`
+}
+
+// UpdateBudgetRequest defines model for UpdateBudgetRequest.
+type UpdateBudgetRequest struct {
+	Categories []BudgetCategory `json:"categories,omitempty" validate:"max=7,dive"`
+
+	// ISO 4217 code of the currency every expense is converted to.
+	Currency string `json:"currency" validate:"required,iso4217"`
+
+	// Amount planned for the whole trip. Defaults to the sum of the categories.
+	Total *string `json:"total,omitempty" validate:"omitempty,numeric"`
+}
+
+// UpdateLinkRequest defines model for UpdateLinkRequest.
+type UpdateLinkRequest struct {
+	Title string `json:"title" validate:"required"`
+	URL   string `json:"url" validate:"required,url"`
+}
+
+// UpdateParticipantRoleRequest defines model for UpdateParticipantRoleRequest.
+type UpdateParticipantRoleRequest struct {
+	// One of editor or viewer.
+	Role string `json:"role" validate:"required,oneof=editor viewer"`
 }
 
 // UpdateTripRequest defines model for UpdateTripRequest.
@@ -132,6 +545,48 @@ type UpdateTripRequest struct {
 	Destination string    `json:"destination" validate:"required,min=4"`
 	EndsAt      time.Time `json:"ends_at" validate:"required"`
 	StartsAt    time.Time `json:"starts_at" validate:"required"`
+
+	// IANA time zone of the trip. Keeps the current one when omitted.
+	TimeZone *string `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+}
+
+// PostInboundItipParams defines parameters for PostInboundItip.
+type PostInboundItipParams struct {
+	// Shared secret set in PLANNER_INBOUND_SECRET.
+	XPlannerInboundSecret *string `json:"X-Planner-Inbound-Secret,omitempty"`
+}
+
+// PatchParticipantsParticipantIDConfirmJSONBody defines parameters for PatchParticipantsParticipantIDConfirm.
+type PatchParticipantsParticipantIDConfirmJSONBody ConfirmParticipantRequest
+
+// PatchParticipantsParticipantIDRoleJSONBody defines parameters for PatchParticipantsParticipantIDRole.
+type PatchParticipantsParticipantIDRoleJSONBody UpdateParticipantRoleRequest
+
+// PatchParticipantsParticipantIDRsvpJSONBody defines parameters for PatchParticipantsParticipantIDRsvp.
+type PatchParticipantsParticipantIDRsvpJSONBody RespondInvitationRequest
+
+// PostSessionsJSONBody defines parameters for PostSessions.
+type PostSessionsJSONBody CreateSessionRequest
+
+// GetTripsParams defines parameters for GetTrips.
+type GetTripsParams struct {
+	// Only trips whose destination contains this text.
+	Destination *string `json:"destination,omitempty"`
+
+	// Only trips that end on or after this date, in their own time zone.
+	From *openapi_types.Date `json:"from,omitempty"`
+
+	// Only trips that start on or before this date, in their own time zone.
+	To *openapi_types.Date `json:"to,omitempty"`
+
+	// Only confirmed or unconfirmed trips.
+	IsConfirmed *bool `json:"is_confirmed,omitempty"`
+
+	// Maximum number of items to return.
+	Limit *int `json:"limit,omitempty"`
+
+	// Opaque cursor returned as next_cursor by the previous page.
+	Cursor *string `json:"cursor,omitempty"`
 }
 
 // PostTripsJSONBody defines parameters for PostTrips.
@@ -140,15 +595,140 @@ type PostTripsJSONBody CreateTripRequest
 // PutTripsTripIDJSONBody defines parameters for PutTripsTripID.
 type PutTripsTripIDJSONBody UpdateTripRequest
 
+// GetTripsTripIDActivitiesParams defines parameters for GetTripsTripIDActivities.
+type GetTripsTripIDActivitiesParams struct {
+	// Only activities on or after this day, in their own time zone.
+	From *openapi_types.Date `json:"from,omitempty"`
+
+	// Only activities on or before this day, in their own time zone.
+	To *openapi_types.Date `json:"to,omitempty"`
+
+	// Only activities that have not ended yet.
+	Upcoming *bool `json:"upcoming,omitempty"`
+
+	// Include the days of the trip without activities, within the requested window.
+	IncludeEmptyDays *bool `json:"include_empty_days,omitempty"`
+
+	// Maximum number of items to return.
+	Limit *int `json:"limit,omitempty"`
+
+	// Opaque cursor returned as next_cursor by the previous page.
+	Cursor *string `json:"cursor,omitempty"`
+}
+
 // PostTripsTripIDActivitiesJSONBody defines parameters for PostTripsTripIDActivities.
 type PostTripsTripIDActivitiesJSONBody CreateActivityRequest
 
+// PostTripsTripIDActivitiesParams defines parameters for PostTripsTripIDActivities.
+type PostTripsTripIDActivitiesParams struct {
+	// What to do when the activity overlaps others: warn about them in the response, or reject it with a 409.
+	OnOverlap *PostTripsTripIDActivitiesParamsOnOverlap `json:"on_overlap,omitempty"`
+}
+
+// PostTripsTripIDActivitiesParamsOnOverlap defines parameters for PostTripsTripIDActivities.
+type PostTripsTripIDActivitiesParamsOnOverlap string
+
+// PutTripsTripIDActivitiesActivityIDJSONBody defines parameters for PutTripsTripIDActivitiesActivityID.
+type PutTripsTripIDActivitiesActivityIDJSONBody UpdateActivityRequest
+
+// PutTripsTripIDActivitiesActivityIDParams defines parameters for PutTripsTripIDActivitiesActivityID.
+type PutTripsTripIDActivitiesActivityIDParams struct {
+	// What to do when the activity overlaps others: accept it, or reject it with a 409.
+	OnOverlap *PutTripsTripIDActivitiesActivityIDParamsOnOverlap `json:"on_overlap,omitempty"`
+}
+
+// PutTripsTripIDActivitiesActivityIDParamsOnOverlap defines parameters for PutTripsTripIDActivitiesActivityID.
+type PutTripsTripIDActivitiesActivityIDParamsOnOverlap string
+
+// PutTripsTripIDBudgetJSONBody defines parameters for PutTripsTripIDBudget.
+type PutTripsTripIDBudgetJSONBody UpdateBudgetRequest
+
+// GetTripsTripIDCalendarIcsParams defines parameters for GetTripsTripIDCalendarIcs.
+type GetTripsTripIDCalendarIcsParams struct {
+	// Token of a calendar subscription, used instead of the session token.
+	Token *string `json:"token,omitempty"`
+}
+
+// GetTripsTripIDExpensesParams defines parameters for GetTripsTripIDExpenses.
+type GetTripsTripIDExpensesParams struct {
+	// Maximum number of items to return.
+	Limit *int `json:"limit,omitempty"`
+
+	// Opaque cursor returned as next_cursor by the previous page.
+	Cursor *string `json:"cursor,omitempty"`
+}
+
+// PostTripsTripIDExpensesJSONBody defines parameters for PostTripsTripIDExpenses.
+type PostTripsTripIDExpensesJSONBody CreateExpenseRequest
+
 // PostTripsTripIDInvitesJSONBody defines parameters for PostTripsTripIDInvites.
 type PostTripsTripIDInvitesJSONBody InviteParticipantRequest
 
+// PostTripsTripIDInvitesBulkJSONBody defines parameters for PostTripsTripIDInvitesBulk.
+type PostTripsTripIDInvitesBulkJSONBody BulkInviteRequest
+
+// GetTripsTripIDLinksParams defines parameters for GetTripsTripIDLinks.
+type GetTripsTripIDLinksParams struct {
+	// Maximum number of items to return.
+	Limit *int `json:"limit,omitempty"`
+
+	// Opaque cursor returned as next_cursor by the previous page.
+	Cursor *string `json:"cursor,omitempty"`
+}
+
 // PostTripsTripIDLinksJSONBody defines parameters for PostTripsTripIDLinks.
 type PostTripsTripIDLinksJSONBody CreateLinkRequest
 
+// PutTripsTripIDLinksLinkIDJSONBody defines parameters for PutTripsTripIDLinksLinkID.
+type PutTripsTripIDLinksLinkIDJSONBody UpdateLinkRequest
+
+// PutTripsTripIDOwnerJSONBody defines parameters for PutTripsTripIDOwner.
+type PutTripsTripIDOwnerJSONBody TransferOwnershipRequest
+
+// GetTripsTripIDParticipantsParams defines parameters for GetTripsTripIDParticipants.
+type GetTripsTripIDParticipantsParams struct {
+	// Maximum number of items to return.
+	Limit *int `json:"limit,omitempty"`
+
+	// Opaque cursor returned as next_cursor by the previous page.
+	Cursor *string `json:"cursor,omitempty"`
+}
+
+// PostUsersJSONBody defines parameters for PostUsers.
+type PostUsersJSONBody CreateUserRequest
+
+// PatchParticipantsParticipantIDConfirmJSONRequestBody defines body for PatchParticipantsParticipantIDConfirm for application/json ContentType.
+type PatchParticipantsParticipantIDConfirmJSONRequestBody PatchParticipantsParticipantIDConfirmJSONBody
+
+// Bind implements render.Binder.
+func (PatchParticipantsParticipantIDConfirmJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchParticipantsParticipantIDRoleJSONRequestBody defines body for PatchParticipantsParticipantIDRole for application/json ContentType.
+type PatchParticipantsParticipantIDRoleJSONRequestBody PatchParticipantsParticipantIDRoleJSONBody
+
+// Bind implements render.Binder.
+func (PatchParticipantsParticipantIDRoleJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PatchParticipantsParticipantIDRsvpJSONRequestBody defines body for PatchParticipantsParticipantIDRsvp for application/json ContentType.
+type PatchParticipantsParticipantIDRsvpJSONRequestBody PatchParticipantsParticipantIDRsvpJSONBody
+
+// Bind implements render.Binder.
+func (PatchParticipantsParticipantIDRsvpJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostSessionsJSONRequestBody defines body for PostSessions for application/json ContentType.
+type PostSessionsJSONRequestBody PostSessionsJSONBody
+
+// Bind implements render.Binder.
+func (PostSessionsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsJSONRequestBody defines body for PostTrips for application/json ContentType.
 type PostTripsJSONRequestBody PostTripsJSONBody
 
@@ -173,6 +753,30 @@ func (PostTripsTripIDActivitiesJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
+// PutTripsTripIDActivitiesActivityIDJSONRequestBody defines body for PutTripsTripIDActivitiesActivityID for application/json ContentType.
+type PutTripsTripIDActivitiesActivityIDJSONRequestBody PutTripsTripIDActivitiesActivityIDJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDActivitiesActivityIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTripsTripIDBudgetJSONRequestBody defines body for PutTripsTripIDBudget for application/json ContentType.
+type PutTripsTripIDBudgetJSONRequestBody PutTripsTripIDBudgetJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDBudgetJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostTripsTripIDExpensesJSONRequestBody defines body for PostTripsTripIDExpenses for application/json ContentType.
+type PostTripsTripIDExpensesJSONRequestBody PostTripsTripIDExpensesJSONBody
+
+// Bind implements render.Binder.
+func (PostTripsTripIDExpensesJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsTripIDInvitesJSONRequestBody defines body for PostTripsTripIDInvites for application/json ContentType.
 type PostTripsTripIDInvitesJSONRequestBody PostTripsTripIDInvitesJSONBody
 
@@ -181,6 +785,14 @@ func (PostTripsTripIDInvitesJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
+// PostTripsTripIDInvitesBulkJSONRequestBody defines body for PostTripsTripIDInvitesBulk for application/json ContentType.
+type PostTripsTripIDInvitesBulkJSONRequestBody PostTripsTripIDInvitesBulkJSONBody
+
+// Bind implements render.Binder.
+func (PostTripsTripIDInvitesBulkJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsTripIDLinksJSONRequestBody defines body for PostTripsTripIDLinks for application/json ContentType.
 type PostTripsTripIDLinksJSONRequestBody PostTripsTripIDLinksJSONBody
 
@@ -189,6 +801,30 @@ func (PostTripsTripIDLinksJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
+// PutTripsTripIDLinksLinkIDJSONRequestBody defines body for PutTripsTripIDLinksLinkID for application/json ContentType.
+type PutTripsTripIDLinksLinkIDJSONRequestBody PutTripsTripIDLinksLinkIDJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDLinksLinkIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTripsTripIDOwnerJSONRequestBody defines body for PutTripsTripIDOwner for application/json ContentType.
+type PutTripsTripIDOwnerJSONRequestBody PutTripsTripIDOwnerJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDOwnerJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody PostUsersJSONBody
+
+// Bind implements render.Binder.
+func (PostUsersJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // Response is a common response struct for all the API calls.
 // A Response object may be instantiated via functions for specific operation responses.
 // It may also be instantiated directly, for the purpose of responding with a single status code.
@@ -230,9 +866,9 @@ func (resp *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.Encode(resp.body)
 }
 
-// PatchParticipantsParticipantIDConfirmJSON204Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// GetConfirmationsAccountsTokenJSON204Response is a constructor method for a GetConfirmationsAccountsToken response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PatchParticipantsParticipantIDConfirmJSON204Response(body interface{}) *Response {
+func GetConfirmationsAccountsTokenJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
 		Code:        204,
@@ -240,79 +876,149 @@ func PatchParticipantsParticipantIDConfirmJSON204Response(body interface{}) *Res
 	}
 }
 
-// PatchParticipantsParticipantIDConfirmJSON400Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// GetConfirmationsParticipantsTokenJSON204Response is a constructor method for a GetConfirmationsParticipantsToken response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PatchParticipantsParticipantIDConfirmJSON400Response(body Error) *Response {
+func GetConfirmationsParticipantsTokenJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsJSON201Response is a constructor method for a PostTrips response.
+// GetConfirmationsTripsTokenJSON204Response is a constructor method for a GetConfirmationsTripsToken response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsJSON201Response(body CreateTripResponse) *Response {
+func GetConfirmationsTripsTokenJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        201,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsJSON400Response is a constructor method for a PostTrips response.
+// PostInboundItipJSON204Response is a constructor method for a PostInboundItip response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsJSON400Response(body Error) *Response {
+func PostInboundItipJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDJSON200Response is a constructor method for a GetTripsTripID response.
+// PatchParticipantsParticipantIDConfirmJSON204Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDJSON200Response(body GetTripDetailsResponse) *Response {
+func PatchParticipantsParticipantIDConfirmJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        200,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDJSON400Response is a constructor method for a GetTripsTripID response.
+// DeleteParticipantsParticipantIDDigestsJSON204Response is a constructor method for a DeleteParticipantsParticipantIDDigests response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDJSON400Response(body Error) *Response {
+func DeleteParticipantsParticipantIDDigestsJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// PutTripsTripIDJSON204Response is a constructor method for a PutTripsTripID response.
+// GetParticipantsParticipantIDDigestsJSON200Response is a constructor method for a GetParticipantsParticipantIDDigests response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PutTripsTripIDJSON204Response(body interface{}) *Response {
+func GetParticipantsParticipantIDDigestsJSON200Response(body DigestSubscriptionResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        204,
+		Code:        200,
 		contentType: "application/json",
 	}
 }
 
-// PutTripsTripIDJSON400Response is a constructor method for a PutTripsTripID response.
+// PutParticipantsParticipantIDDigestsJSON204Response is a constructor method for a PutParticipantsParticipantIDDigests response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PutTripsTripIDJSON400Response(body Error) *Response {
+func PutParticipantsParticipantIDDigestsJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDActivitiesJSON200Response is a constructor method for a GetTripsTripIDActivities response.
+// PatchParticipantsParticipantIDRoleJSON204Response is a constructor method for a PatchParticipantsParticipantIDRole response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Response {
+func PatchParticipantsParticipantIDRoleJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PatchParticipantsParticipantIDRsvpJSON204Response is a constructor method for a PatchParticipantsParticipantIDRsvp response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchParticipantsParticipantIDRsvpJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// DeleteSessionsJSON204Response is a constructor method for a DeleteSessions response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteSessionsJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostSessionsJSON201Response is a constructor method for a PostSessions response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostSessionsJSON201Response(body CreateSessionResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsJSON200Response is a constructor method for a GetTrips response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsJSON200Response(body GetTripsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsJSON201Response is a constructor method for a PostTrips response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsJSON201Response(body CreateTripResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDJSON204Response is a constructor method for a DeleteTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDJSON200Response is a constructor method for a GetTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDJSON200Response(body GetTripDetailsResponse) *Response {
 	return &Response{
 		body:        body,
 		Code:        200,
@@ -320,12 +1026,22 @@ func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Re
 	}
 }
 
-// GetTripsTripIDActivitiesJSON400Response is a constructor method for a GetTripsTripIDActivities response.
+// PutTripsTripIDJSON204Response is a constructor method for a PutTripsTripID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDActivitiesJSON200Response is a constructor method for a GetTripsTripIDActivities response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDActivitiesJSON400Response(body Error) *Response {
+func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        200,
 		contentType: "application/json",
 	}
 }
@@ -340,12 +1056,82 @@ func PostTripsTripIDActivitiesJSON201Response(body CreateActivityResponse) *Resp
 	}
 }
 
-// PostTripsTripIDActivitiesJSON400Response is a constructor method for a PostTripsTripIDActivities response.
+// GetTripsTripIDActivitiesConflictsJSON200Response is a constructor method for a GetTripsTripIDActivitiesConflicts response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDActivitiesConflictsJSON200Response(body ActivityConflictsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDActivitiesActivityIDJSON204Response is a constructor method for a DeleteTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDActivitiesActivityIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON200Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON200Response(body UpdateActivityResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDBalancesJSON200Response is a constructor method for a GetTripsTripIDBalances response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDBalancesJSON200Response(body GetBalancesResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDBudgetJSON200Response is a constructor method for a GetTripsTripIDBudget response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDBudgetJSON200Response(body GetBudgetResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDBudgetJSON204Response is a constructor method for a PutTripsTripIDBudget response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDBudgetJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDCalendarSubscriptionJSON204Response is a constructor method for a DeleteTripsTripIDCalendarSubscription response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDCalendarSubscriptionJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDCalendarSubscriptionJSON201Response is a constructor method for a PostTripsTripIDCalendarSubscription response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDActivitiesJSON400Response(body Error) *Response {
+func PostTripsTripIDCalendarSubscriptionJSON201Response(body CalendarSubscriptionResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        201,
 		contentType: "application/json",
 	}
 }
@@ -360,153 +1146,1281 @@ func GetTripsTripIDConfirmJSON204Response(body interface{}) *Response {
 	}
 }
 
-// GetTripsTripIDConfirmJSON400Response is a constructor method for a GetTripsTripIDConfirm response.
+// GetTripsTripIDExpensesJSON200Response is a constructor method for a GetTripsTripIDExpenses response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDExpensesJSON200Response(body GetExpensesResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDExpensesJSON201Response is a constructor method for a PostTripsTripIDExpenses response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDExpensesJSON201Response(body CreateExpenseResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDExpensesExpenseIDJSON204Response is a constructor method for a DeleteTripsTripIDExpensesExpenseID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDExpensesExpenseIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesJSON201Response is a constructor method for a PostTripsTripIDInvites response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesJSON201Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesBulkJSON200Response is a constructor method for a PostTripsTripIDInvitesBulk response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesBulkJSON200Response(body BulkInviteResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDLinksJSON200Response is a constructor method for a GetTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDLinksJSON200Response(body GetLinksResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDLinksJSON201Response is a constructor method for a PostTripsTripIDLinks response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDLinksJSON201Response(body CreateLinkResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDLinksLinkIDJSON204Response is a constructor method for a DeleteTripsTripIDLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDLinksLinkIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDLinksLinkIDJSON204Response is a constructor method for a PutTripsTripIDLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDLinksLinkIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDOwnerJSON204Response is a constructor method for a PutTripsTripIDOwner response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDOwnerJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDParticipantsJSON200Response is a constructor method for a GetTripsTripIDParticipants response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDParticipantsJSON200Response(body GetTripParticipantsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDParticipantsParticipantIDJSON204Response is a constructor method for a DeleteTripsTripIDParticipantsParticipantID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDParticipantsParticipantIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostUsersJSON201Response is a constructor method for a PostUsers response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostUsersJSON201Response(body CreateUserResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostUsersJSON202Response is a constructor method for a PostUsers response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDConfirmJSON400Response(body Error) *Response {
+func PostUsersJSON202Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        202,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsTripIDInvitesJSON201Response is a constructor method for a PostTripsTripIDInvites response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDInvitesJSON201Response(body interface{}) *Response {
-	return &Response{
-		body:        body,
-		Code:        201,
-		contentType: "application/json",
-	}
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Claim an account from the link sent by e-mail.
+	// (GET /confirmations/accounts/{token})
+	GetConfirmationsAccountsToken(w http.ResponseWriter, r *http.Request, token string) *Response
+	// Confirm a participant from the link sent by e-mail.
+	// (GET /confirmations/participants/{token})
+	GetConfirmationsParticipantsToken(w http.ResponseWriter, r *http.Request, token string) *Response
+	// Confirm a trip from the link sent by e-mail.
+	// (GET /confirmations/trips/{token})
+	GetConfirmationsTripsToken(w http.ResponseWriter, r *http.Request, token string) *Response
+	// Process an iTIP reply to a trip invitation.
+	// (POST /inbound/itip)
+	PostInboundItip(w http.ResponseWriter, r *http.Request, params PostInboundItipParams) *Response
+	// Confirms a participant on a trip.
+	// (PATCH /participants/{participantId}/confirm)
+	PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string) *Response
+	// Stop sending a participant the digest e-mails of their trip.
+	// (DELETE /participants/{participantId}/digests)
+	DeleteParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request, participantID string) *Response
+	// Tell whether a participant receives the digest e-mails of their trip.
+	// (GET /participants/{participantId}/digests)
+	GetParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request, participantID string) *Response
+	// Send a participant the digest e-mails of their trip again.
+	// (PUT /participants/{participantId}/digests)
+	PutParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request, participantID string) *Response
+	// Change the role of a participant on a trip.
+	// (PATCH /participants/{participantId}/role)
+	PatchParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request, participantID string) *Response
+	// Answer the invitation of a participant to a trip.
+	// (PATCH /participants/{participantId}/rsvp)
+	PatchParticipantsParticipantIDRsvp(w http.ResponseWriter, r *http.Request, participantID string) *Response
+	// Log out and revoke the current session token.
+	// (DELETE /sessions)
+	DeleteSessions(w http.ResponseWriter, r *http.Request) *Response
+	// Log in and create a session token.
+	// (POST /sessions)
+	PostSessions(w http.ResponseWriter, r *http.Request) *Response
+	// List the trips the user owns or takes part in.
+	// (GET /trips)
+	GetTrips(w http.ResponseWriter, r *http.Request, params GetTripsParams) *Response
+	// Create a new trip
+	// (POST /trips)
+	PostTrips(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a trip and everything attached to it.
+	// (DELETE /trips/{tripId})
+	DeleteTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip details.
+	// (GET /trips/{tripId})
+	GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Update a trip.
+	// (PUT /trips/{tripId})
+	PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip activities.
+	// (GET /trips/{tripId}/activities)
+	GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDActivitiesParams) *Response
+	// Create a trip activity.
+	// (POST /trips/{tripId}/activities)
+	PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string, params PostTripsTripIDActivitiesParams) *Response
+	// Report the conflicts between the activities of a trip.
+	// (GET /trips/{tripId}/activities/conflicts)
+	GetTripsTripIDActivitiesConflicts(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Delete a trip activity.
+	// (DELETE /trips/{tripId}/activities/{activityId})
+	DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *Response
+	// Update a trip activity.
+	// (PUT /trips/{tripId}/activities/{activityId})
+	PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string, params PutTripsTripIDActivitiesActivityIDParams) *Response
+	// Get the balances of a trip and the transfers that settle them.
+	// (GET /trips/{tripId}/balances)
+	GetTripsTripIDBalances(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get the budget of a trip against its expenses, by activity category.
+	// (GET /trips/{tripId}/budget)
+	GetTripsTripIDBudget(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Set the budget of a trip.
+	// (PUT /trips/{tripId}/budget)
+	PutTripsTripIDBudget(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Export a trip and its activities as an iCalendar file.
+	// (GET /trips/{tripId}/calendar.ics)
+	GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDCalendarIcsParams) *Response
+	// Revoke the calendar subscription of the caller for a trip.
+	// (DELETE /trips/{tripId}/calendar/subscription)
+	DeleteTripsTripIDCalendarSubscription(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Create the calendar subscription of the caller for a trip, replacing any previous one.
+	// (POST /trips/{tripId}/calendar/subscription)
+	PostTripsTripIDCalendarSubscription(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Confirm a trip and send e-mail invitations.
+	// (GET /trips/{tripId}/confirm)
+	GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip expenses.
+	// (GET /trips/{tripId}/expenses)
+	GetTripsTripIDExpenses(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDExpensesParams) *Response
+	// Create a trip expense.
+	// (POST /trips/{tripId}/expenses)
+	PostTripsTripIDExpenses(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Delete a trip expense.
+	// (DELETE /trips/{tripId}/expenses/{expenseId})
+	DeleteTripsTripIDExpensesExpenseID(w http.ResponseWriter, r *http.Request, tripID string, expenseID string) *Response
+	// Invite someone to the trip.
+	// (POST /trips/{tripId}/invites)
+	PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Invite many people to the trip at once.
+	// (POST /trips/{tripId}/invites/bulk)
+	PostTripsTripIDInvitesBulk(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip links.
+	// (GET /trips/{tripId}/links)
+	GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDLinksParams) *Response
+	// Create a trip link.
+	// (POST /trips/{tripId}/links)
+	PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Delete a trip link.
+	// (DELETE /trips/{tripId}/links/{linkId})
+	DeleteTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *Response
+	// Update a trip link.
+	// (PUT /trips/{tripId}/links/{linkId})
+	PutTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *Response
+	// Hand a trip over to another participant.
+	// (PUT /trips/{tripId}/owner)
+	PutTripsTripIDOwner(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip participants.
+	// (GET /trips/{tripId}/participants)
+	GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDParticipantsParams) *Response
+	// Remove a participant from a trip.
+	// (DELETE /trips/{tripId}/participants/{participantId})
+	DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Create a user account.
+	// (POST /users)
+	PostUsers(w http.ResponseWriter, r *http.Request) *Response
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler          ServerInterface
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// GetConfirmationsAccountsToken operation middleware
+func (siw *ServerInterfaceWrapper) GetConfirmationsAccountsToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	if err := runtime.BindStyledParameter("simple", false, "token", chi.URLParam(r, "token"), &token); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetConfirmationsAccountsToken(w, r, token)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetConfirmationsParticipantsToken operation middleware
+func (siw *ServerInterfaceWrapper) GetConfirmationsParticipantsToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	if err := runtime.BindStyledParameter("simple", false, "token", chi.URLParam(r, "token"), &token); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetConfirmationsParticipantsToken(w, r, token)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetConfirmationsTripsToken operation middleware
+func (siw *ServerInterfaceWrapper) GetConfirmationsTripsToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	if err := runtime.BindStyledParameter("simple", false, "token", chi.URLParam(r, "token"), &token); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetConfirmationsTripsToken(w, r, token)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostInboundItip operation middleware
+func (siw *ServerInterfaceWrapper) PostInboundItip(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostInboundItipParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Planner-Inbound-Secret" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Planner-Inbound-Secret")]; found {
+		var XPlannerInboundSecret string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "X-Planner-Inbound-Secret"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "X-Planner-Inbound-Secret", runtime.ParamLocationHeader, valueList[0], &XPlannerInboundSecret); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "X-Planner-Inbound-Secret"})
+			return
+		}
+
+		params.XPlannerInboundSecret = &XPlannerInboundSecret
+
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostInboundItip(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchParticipantsParticipantIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchParticipantsParticipantIDConfirm(w, r, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteParticipantsParticipantIDDigests operation middleware
+func (siw *ServerInterfaceWrapper) DeleteParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteParticipantsParticipantIDDigests(w, r, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetParticipantsParticipantIDDigests operation middleware
+func (siw *ServerInterfaceWrapper) GetParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetParticipantsParticipantIDDigests(w, r, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutParticipantsParticipantIDDigests operation middleware
+func (siw *ServerInterfaceWrapper) PutParticipantsParticipantIDDigests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutParticipantsParticipantIDDigests(w, r, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchParticipantsParticipantIDRole operation middleware
+func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchParticipantsParticipantIDRole(w, r, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchParticipantsParticipantIDRsvp operation middleware
+func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDRsvp(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchParticipantsParticipantIDRsvp(w, r, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteSessions(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostSessions operation middleware
+func (siw *ServerInterfaceWrapper) PostSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostSessions(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTrips operation middleware
+func (siw *ServerInterfaceWrapper) GetTrips(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsParams
+
+	// ------------- Optional query parameter "destination" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "destination", r.URL.Query(), &params.Destination); err != nil {
+		err = fmt.Errorf("invalid format for parameter destination: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "destination"})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From); err != nil {
+		err = fmt.Errorf("invalid format for parameter from: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "from"})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To); err != nil {
+		err = fmt.Errorf("invalid format for parameter to: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "to"})
+		return
+	}
+
+	// ------------- Optional query parameter "is_confirmed" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "is_confirmed", r.URL.Query(), &params.IsConfirmed); err != nil {
+		err = fmt.Errorf("invalid format for parameter is_confirmed: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "is_confirmed"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTrips(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTrips operation middleware
+func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTrips(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripID(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripID(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTripsTripID(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDActivities operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDActivitiesParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From); err != nil {
+		err = fmt.Errorf("invalid format for parameter from: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "from"})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To); err != nil {
+		err = fmt.Errorf("invalid format for parameter to: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "to"})
+		return
+	}
+
+	// ------------- Optional query parameter "upcoming" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "upcoming", r.URL.Query(), &params.Upcoming); err != nil {
+		err = fmt.Errorf("invalid format for parameter upcoming: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "upcoming"})
+		return
+	}
+
+	// ------------- Optional query parameter "include_empty_days" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "include_empty_days", r.URL.Query(), &params.IncludeEmptyDays); err != nil {
+		err = fmt.Errorf("invalid format for parameter include_empty_days: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "include_empty_days"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDActivities(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDActivities operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTripsTripIDActivitiesParams
+
+	// ------------- Optional query parameter "on_overlap" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "on_overlap", r.URL.Query(), &params.OnOverlap); err != nil {
+		err = fmt.Errorf("invalid format for parameter on_overlap: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "on_overlap"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDActivities(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDActivitiesConflicts operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDActivitiesConflicts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDActivitiesConflicts(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTripsTripIDActivitiesActivityID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "activityId" -------------
+	var activityID string
+
+	if err := runtime.BindStyledParameter("simple", false, "activityId", chi.URLParam(r, "activityId"), &activityID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "activityId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripIDActivitiesActivityID(w, r, tripID, activityID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTripsTripIDActivitiesActivityID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "activityId" -------------
+	var activityID string
+
+	if err := runtime.BindStyledParameter("simple", false, "activityId", chi.URLParam(r, "activityId"), &activityID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "activityId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutTripsTripIDActivitiesActivityIDParams
+
+	// ------------- Optional query parameter "on_overlap" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "on_overlap", r.URL.Query(), &params.OnOverlap); err != nil {
+		err = fmt.Errorf("invalid format for parameter on_overlap: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "on_overlap"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTripsTripIDActivitiesActivityID(w, r, tripID, activityID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDBalances operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDBalances(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDBalances(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDBudget operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDBudget(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTripsTripIDBudget operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTripsTripIDBudget(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDCalendarIcs operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDCalendarIcsParams
+
+	// ------------- Optional query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDCalendarIcs(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTripsTripIDCalendarSubscription operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripIDCalendarSubscription(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDCalendarSubscription operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDCalendarSubscription(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDInvitesJSON400Response is a constructor method for a PostTripsTripIDInvites response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDInvitesJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
-	}
-}
+// GetTripsTripIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// GetTripsTripIDLinksJSON200Response is a constructor method for a GetTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDLinksJSON200Response(body GetLinksResponse) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
-	}
-}
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
 
-// GetTripsTripIDLinksJSON400Response is a constructor method for a GetTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDLinksJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
 	}
-}
 
-// PostTripsTripIDLinksJSON201Response is a constructor method for a PostTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDLinksJSON201Response(body CreateLinkResponse) *Response {
-	return &Response{
-		body:        body,
-		Code:        201,
-		contentType: "application/json",
-	}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDConfirm(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDLinksJSON400Response is a constructor method for a PostTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDLinksJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
+// GetTripsTripIDExpenses operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDExpenses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
 	}
-}
 
-// GetTripsTripIDParticipantsJSON200Response is a constructor method for a GetTripsTripIDParticipants response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDParticipantsJSON200Response(body GetTripParticipantsResponse) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDExpensesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
 	}
-}
 
-// GetTripsTripIDParticipantsJSON400Response is a constructor method for a GetTripsTripIDParticipants response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDParticipantsJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
 	}
-}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Confirms a participant on a trip.
-	// (PATCH /participants/{participantId}/confirm)
-	PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string) *Response
-	// Create a new trip
-	// (POST /trips)
-	PostTrips(w http.ResponseWriter, r *http.Request) *Response
-	// Get a trip details.
-	// (GET /trips/{tripId})
-	GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Update a trip.
-	// (PUT /trips/{tripId})
-	PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip activities.
-	// (GET /trips/{tripId}/activities)
-	GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Create a trip activity.
-	// (POST /trips/{tripId}/activities)
-	PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Confirm a trip and send e-mail invitations.
-	// (GET /trips/{tripId}/confirm)
-	GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Invite someone to the trip.
-	// (POST /trips/{tripId}/invites)
-	PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip links.
-	// (GET /trips/{tripId}/links)
-	GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Create a trip link.
-	// (POST /trips/{tripId}/links)
-	PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip participants.
-	// (GET /trips/{tripId}/participants)
-	GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *Response
-}
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDExpenses(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler          ServerInterface
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	handler(w, r.WithContext(ctx))
 }
 
-// PatchParticipantsParticipantIDConfirm operation middleware
-func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDExpenses operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDExpenses(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "participantId" -------------
-	var participantID string
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
 
-	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PatchParticipantsParticipantIDConfirm(w, r, participantID)
+		resp := siw.Handler.PostTripsTripIDExpenses(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -519,12 +2433,30 @@ func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTrips operation middleware
-func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Request) {
+// DeleteTripsTripIDExpensesExpenseID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDExpensesExpenseID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "expenseId" -------------
+	var expenseID string
+
+	if err := runtime.BindStyledParameter("simple", false, "expenseId", chi.URLParam(r, "expenseId"), &expenseID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "expenseId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTrips(w, r)
+		resp := siw.Handler.DeleteTripsTripIDExpensesExpenseID(w, r, tripID, expenseID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -537,8 +2469,8 @@ func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripID operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDInvites operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -549,8 +2481,10 @@ func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripID(w, r, tripID)
+		resp := siw.Handler.PostTripsTripIDInvites(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -563,8 +2497,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// PutTripsTripID operation middleware
-func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDInvitesBulk operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDInvitesBulk(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -575,8 +2509,10 @@ func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PutTripsTripID(w, r, tripID)
+		resp := siw.Handler.PostTripsTripIDInvitesBulk(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -589,8 +2525,8 @@ func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDActivities operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+// GetTripsTripIDLinks operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -601,8 +2537,29 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWrite
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDLinksParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDActivities(w, r, tripID)
+		resp := siw.Handler.GetTripsTripIDLinks(w, r, tripID, params)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -615,8 +2572,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWrite
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDActivities operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDLinks operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -627,8 +2584,10 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWrit
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDActivities(w, r, tripID)
+		resp := siw.Handler.PostTripsTripIDLinks(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -641,8 +2600,8 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWrit
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDConfirm operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request) {
+// DeleteTripsTripIDLinksLinkID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -653,8 +2612,18 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter,
 		return
 	}
 
+	// ------------- Path parameter "linkId" -------------
+	var linkID string
+
+	if err := runtime.BindStyledParameter("simple", false, "linkId", chi.URLParam(r, "linkId"), &linkID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "linkId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDConfirm(w, r, tripID)
+		resp := siw.Handler.DeleteTripsTripIDLinksLinkID(w, r, tripID, linkID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -667,8 +2636,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDInvites operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request) {
+// PutTripsTripIDLinksLinkID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -679,8 +2648,18 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter,
 		return
 	}
 
+	// ------------- Path parameter "linkId" -------------
+	var linkID string
+
+	if err := runtime.BindStyledParameter("simple", false, "linkId", chi.URLParam(r, "linkId"), &linkID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "linkId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDInvites(w, r, tripID)
+		resp := siw.Handler.PutTripsTripIDLinksLinkID(w, r, tripID, linkID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -693,8 +2672,8 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDLinks operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
+// PutTripsTripIDOwner operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDOwner(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -705,8 +2684,10 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDLinks(w, r, tripID)
+		resp := siw.Handler.PutTripsTripIDOwner(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -719,8 +2700,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDLinks operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
+// GetTripsTripIDParticipants operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -731,8 +2712,29 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDParticipantsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor); err != nil {
+		err = fmt.Errorf("invalid format for parameter cursor: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "cursor"})
+		return
+	}
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDLinks(w, r, tripID)
+		resp := siw.Handler.GetTripsTripIDParticipants(w, r, tripID, params)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -745,8 +2747,8 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDParticipants operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request) {
+// DeleteTripsTripIDParticipantsParticipantID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -757,8 +2759,36 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWri
 		return
 	}
 
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripIDParticipantsParticipantID(w, r, tripID, participantID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDParticipants(w, r, tripID)
+		resp := siw.Handler.PostUsers(w, r)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -886,17 +2916,48 @@ func Handler(si ServerInterface, opts ...ServerOption) http.Handler {
 	}
 
 	r.Route(options.BaseURL, func(r chi.Router) {
+		r.Get("/confirmations/accounts/{token}", wrapper.GetConfirmationsAccountsToken)
+		r.Get("/confirmations/participants/{token}", wrapper.GetConfirmationsParticipantsToken)
+		r.Get("/confirmations/trips/{token}", wrapper.GetConfirmationsTripsToken)
+		r.Post("/inbound/itip", wrapper.PostInboundItip)
 		r.Patch("/participants/{participantId}/confirm", wrapper.PatchParticipantsParticipantIDConfirm)
+		r.Delete("/participants/{participantId}/digests", wrapper.DeleteParticipantsParticipantIDDigests)
+		r.Get("/participants/{participantId}/digests", wrapper.GetParticipantsParticipantIDDigests)
+		r.Put("/participants/{participantId}/digests", wrapper.PutParticipantsParticipantIDDigests)
+		r.Patch("/participants/{participantId}/role", wrapper.PatchParticipantsParticipantIDRole)
+		r.Patch("/participants/{participantId}/rsvp", wrapper.PatchParticipantsParticipantIDRsvp)
+		r.Delete("/sessions", wrapper.DeleteSessions)
+		r.Post("/sessions", wrapper.PostSessions)
+		r.Get("/trips", wrapper.GetTrips)
 		r.Post("/trips", wrapper.PostTrips)
+		r.Delete("/trips/{tripId}", wrapper.DeleteTripsTripID)
 		r.Get("/trips/{tripId}", wrapper.GetTripsTripID)
 		r.Put("/trips/{tripId}", wrapper.PutTripsTripID)
 		r.Get("/trips/{tripId}/activities", wrapper.GetTripsTripIDActivities)
 		r.Post("/trips/{tripId}/activities", wrapper.PostTripsTripIDActivities)
+		r.Get("/trips/{tripId}/activities/conflicts", wrapper.GetTripsTripIDActivitiesConflicts)
+		r.Delete("/trips/{tripId}/activities/{activityId}", wrapper.DeleteTripsTripIDActivitiesActivityID)
+		r.Put("/trips/{tripId}/activities/{activityId}", wrapper.PutTripsTripIDActivitiesActivityID)
+		r.Get("/trips/{tripId}/balances", wrapper.GetTripsTripIDBalances)
+		r.Get("/trips/{tripId}/budget", wrapper.GetTripsTripIDBudget)
+		r.Put("/trips/{tripId}/budget", wrapper.PutTripsTripIDBudget)
+		r.Get("/trips/{tripId}/calendar.ics", wrapper.GetTripsTripIDCalendarIcs)
+		r.Delete("/trips/{tripId}/calendar/subscription", wrapper.DeleteTripsTripIDCalendarSubscription)
+		r.Post("/trips/{tripId}/calendar/subscription", wrapper.PostTripsTripIDCalendarSubscription)
 		r.Get("/trips/{tripId}/confirm", wrapper.GetTripsTripIDConfirm)
+		r.Get("/trips/{tripId}/expenses", wrapper.GetTripsTripIDExpenses)
+		r.Post("/trips/{tripId}/expenses", wrapper.PostTripsTripIDExpenses)
+		r.Delete("/trips/{tripId}/expenses/{expenseId}", wrapper.DeleteTripsTripIDExpensesExpenseID)
 		r.Post("/trips/{tripId}/invites", wrapper.PostTripsTripIDInvites)
+		r.Post("/trips/{tripId}/invites/bulk", wrapper.PostTripsTripIDInvitesBulk)
 		r.Get("/trips/{tripId}/links", wrapper.GetTripsTripIDLinks)
 		r.Post("/trips/{tripId}/links", wrapper.PostTripsTripIDLinks)
+		r.Delete("/trips/{tripId}/links/{linkId}", wrapper.DeleteTripsTripIDLinksLinkID)
+		r.Put("/trips/{tripId}/links/{linkId}", wrapper.PutTripsTripIDLinksLinkID)
+		r.Put("/trips/{tripId}/owner", wrapper.PutTripsTripIDOwner)
 		r.Get("/trips/{tripId}/participants", wrapper.GetTripsTripIDParticipants)
+		r.Delete("/trips/{tripId}/participants/{participantId}", wrapper.DeleteTripsTripIDParticipantsParticipantID)
+		r.Post("/users", wrapper.PostUsers)
 	})
 	return r
 }
@@ -922,30 +2983,117 @@ func WithErrorHandler(handler func(w http.ResponseWriter, r *http.Request, err e
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+Raz27bOBN/FYLfd5TjdDcnAXtom6LwItgGRRd7KIqAlsY2E4lUyZFTw/DT7GFPe9wn",
-	"yIstSMo2Jcs2rcSbOr20tiJy/vxmfjNDek4TmRdSgEBN4znVyQRyZj++VcAQXifIpxxnH+FrCRrNH1ia",
-	"cuRSsOxayQIUctA0HrFMQ0QL79GcyiQplb5hdt1Iqtx8oilD6CHPgUYUZwXQmGpUXIxpRL/1xrIH31Cx",
-	"HrKx3WTKMm6W0Jgq+FpyBSldLCKKHDMwL3TeYxGtv8WfPW2Xm39ZKSiHt5AgXUQbftGFFBoOdAyrlg/S",
-	"mmfKkqcbTmmq6a3drt8VF3fdMHu8WyNaqqxul+KdsY7MZhtYOS2dpH1e6IRQxsVdF3Sqddt1+qR40Q2Z",
-	"FDRywczb5mvOxRWIMU5ofNHZuTkXv1xYIyBnPNM3KG+4mHK0/uIIua75wL616YTVA6YUm4WLT/kUIren",
-	"1UGkx2ILeS9A3ThR+w0KNmCtuxMgWP7Y5NHIFB7HDY1Y9QPKl7sGoiUsapbW/bov6DslIipedEnEal2b",
-	"Tu+UkmqvGinoRPHCpRt9w1KiqrRtqpiD1mzcgntTp+WLbUq9BzR0pR/BV7qWs/9XMKIx/V9/XeL7VX3v",
-	"N4W9tmnbTOM2btNByrv9DrOAh4C8tewHVp2mSU7GnmLyHtAEcFXzOejHVf2lvYFAtYv+UCKoMNg8sQdZ",
-	"NxBiKeIoSB7aHe4AfxeqazEHWe85+PlQ9iDYQDmijuDDfNekfmapPCw0LgFNEXgEgQc6oCHIPPowvG2l",
-	"9gP0XW5ztG7r4M5lEYXmCNc3iRQjrnJIvbgfSpkBE7RDu9CaKyGdQE2VHd6/Zgp5wgsmsGvIFN4WhyZR",
-	"m/gwnqxJPdDALkQR2oyuoqVDdCz7UVFmGRsa7kRVQlBMVA3eUqe98A9sf+g5p9uUc7QWvWHj9pb19yL9",
-	"nue0481I39PksQmM2YOLkaxc7PXm73QBCR/xhD389fAPaJIy8vp6QAqmGJFkyJK7HojUPGZF5l77U5Ii",
-	"Y0KcgSKJFBpV+fB3ykhaKiYQiCS/Xf1BfpWlEjAzKz/K5A5QA8OzVXMR0+UeNKJTUNrp8+rs/OzcdjgF",
-	"CFZwGtOf7aOIFgwn1k19n236c+/bIF30q0xzXIjJxB6mFaCsx8w0RK/NY5+JvM+Dy7fVeiNQsRwQlKbx",
-	"5znlRj+jxDLBY1oTTX2cHFU4fg0ZwL6YxY4PrY0/nV+Y/xIpEITLosL631jRv9UuP9b7gyhzEx2GrEwA",
-	"1EnLBkAd+EsYsTJDsiozi4henJ8fJHRXSXGDYotgfxq0mVPmOVMzGtPK85ow4jmWSEEYMX2LDR6bKs2C",
-	"Y/bpm1dcCZSOdxqoS21LkK5wAo1vZDp7MoM3j6gaqWuB2ID51VEUWGJ6GrhbxQkjAu4t0B7ODlQP4P7c",
-	"nU4sjCJjaAG6ajW0+WdwGZTH1YHH0ybw0/l0yyxxGui+B6zyl6TOgLMWfCNalG1JWz4blk/PEJvNURBD",
-	"/HiFwDmqhfW3s0G/fnRQEUNd4KcJ10TJEoHc8ywjCrBUgrAsIzgBYmRqMgS8BxD2iQ3aVYdFmEhJ1WO5",
-	"lyMCU/uq1GZLnMgSyVoRo/kualqfWbwgkmo56Ts5nqpDuAw+/8DH8NXuLuNZIT5Wd9O80n6WDmfj/vjE",
-	"uhw/xGZbA6yF4rzJJqDxOWSOOQq1/LADzApjkRJthmfo5YxnxN4CWlV0YFFz94YhQ43DfFC9f9pcs/U0",
-	"7Ah08xLCzvmLaJmDFEBQrpqXkIl5HW2re9AAdrFXli+kbanfHZ9ct2Jh85Gu7ppDe5T/HspjtSf+L7ee",
-	"pTWp/WjqFNsSEzptodTCFs2LpgDS8M9cX9DI03prd3I04uO5q24sFv8GAAD//6I5Fyf6KgAA",
+	"H4sIAAAAAAAC/+x923LbuJb2q6D0/3c/Iyvu5O9uV/VFOsnu7T3pJBUne09VT8oFkUsStkmAAUA76pSf",
+	"Zi7mai7nCfaLTeHEIyiJlHwMrmxJJLAArPVhYZ3wbRKzLGcUqBSTk28TEa8gw/rfF7Ekl0SuXzK6SEks",
+	"xQcQOaMC1I84SYgkjOL0PWc5cElATE4WOBUQTfLaV98mrJDnbHHOMV3qVxMQMSe5enty4nohIJBcYYkW",
+	"OE0RK6QgCSC5ApTgtUBsof+XnOTTSTQhEjLd9v/lsJicTP7PUTWKIzuEo99AfuQkrzpw9J9SCvwF53g9",
+	"uY4mcp3D5GSC3Wd2CTzFueiS+h4TrknBLZrtKxFiPAEOCZqvNblCYi4d7QvChUSMws4DcAvwzjTfJfY6",
+	"mnD4UhAOyeTkj4ryqDnnn8v32PyfEEvVULvtYUtaTYD6dMjVyPDXU9PccTTJCK0+bBx6jSDfaH8tkiXI",
+	"l1jCkvH10MFmrKDSw7n6e5SnmFJI0IJxvc6x7SVCWCCMEohJhlNEi2wOHBFqHio4BxqvHXPMNYGKM+Ar",
+	"zvJUkf98NpvOZpNyOEJyQpeTaPL1yZI9ga+S4ycSLzWNlzglCZbqMTctES0y4CTWcxXXht4cxTsKjgg7",
+	"iWs3BAJiOr57RoEtflkwliDJMRU54xIJslxJAUDoEqUsWeq/QETBAYkVy3P1BZMr4JPr9hqXY4jckmxf",
+	"6g+guh3O3QVOu1P1+msOVIBozRepvqnWPmb0EriEBEm2ZcWb89tarM6Plt1GsCMt0hRdrYAiItEKC0Qt",
+	"GKkf8FzxnOQFeAjikGFC1YcuJtruMkILgczMRYjCEktyCaY7hUu18W7prn/V3dAjt0J1yvy8kF6c0ksi",
+	"4QN8KUAMZQTVeOrZCF4kCQehOEEyRHQHEQKi2BbNMQfEuBL+tzgD9B/FbPZDjM0b+kNzA+hMdgPndpa2",
+	"jNBfnkYZ/vrL89lMT2LKYpxCr8ADjVAun/z6QRELYopewQIXqRSOYc379Z0XsSsKfDgksEyNNpdriwlA",
+	"bc8gulJu53zbco7SRL4UUEBy3resum2sPiB4op9B5o1Ibdso1/Ctuk+QXc/aXBAqYalgSw1HqInceWts",
+	"jKtI5daN3nUQtUa0ddJU4yNEoDtVH1eAKOMZTsmf1XS0MIYINV+KD9Q8LdTjcnJim9wBdgjNC+nv23ao",
+	"pIxIdIUFEkD9aMoKGbOsXxDskkYIpxxwsj7POajGlFzUyPeAIhaMdpv9x8oof47E5iwMAz8zA1E5ZW4s",
+	"Ze++FX+JU6AJ5mfFvKRrpMAUPO1FPxTbfhDOc/WJImF6nAOSbIpOJYox50ZLVl9dKMwRDF0A5GrZck4u",
+	"sYQGcxScTLbNiqLKO3BGF4Rn7zGXJCY5pnIc7FPsYxeN5pKhudZUrii6InI1Rf8GkIvaDq9VfCMCKSyk",
+	"Osrsg5gK0Y+fPzc42R0xByzB6fLjRrtVNVQqXFTpcFFdiYucFhc5NS6q6XHcqHLNvcV8tfcmso9iGU2S",
+	"gmugP1d6iwTPZvBXdoVSRpdNzTjFQooIESok4MTso4k4x3qNM0JJVmSTk6edXWHAgpcb+bNn//+Z2cpt",
+	"Hz6sAdokUD06RS8xpUwqVl2SS6AI65EohkXtkTekT1HzRBKNMGOXB77GaZFAcq66++WV7e53O89aM8GS",
+	"yCLxyNgb+4vTOpQOon6aot8L4R+P+k+/0xwIKxTI6vOkWZOfZ7UFevLzrBygOZjtrGmZYb1xvUbVwMth",
+	"Oe3LDMqzZhyai7bCuTpZREgU8UofG2m5fVg1XmGSmhWsFPxYDzbDX98AXcrV5OT4+fNDQIwi247Lszbu",
+	"pyGLY6dky9o8/amxOPrjXquDZWdxyoGpYVJmZb4+h7PZbM9JnFnVm8VxwZ3EHkC43Oh046qF8z8Z9SzR",
+	"6Yu3L5D6Hanf28f6yJwJOcnVQY0zoZSmS+A41Y93jgBYts1uYydHkaQpNvRLcywZPQdts1c5267xzzts",
+	"laP0ITeVp0ljZYuCJF7V0xjX1N5z3jSZbbCBAqJwpZUI+7qI7DGanttvlE55hTm9WVOo38Smxt47sv6J",
+	"t7aTcSqK6/qcJL2TZ5RuMN2gOSiBV4wcIbJAmK6bWqZvwXbnZ/369XVph+q1xWCS7GQGjAxcYokyJqR6",
+	"I8N0Xb6kId8ddlZYNO2ETw9pJ7QEebDl7B16dvz0RxSzagtwj+9hJiSCqWZ1740exwJEYz/L8Rq4l21q",
+	"pwR0tWJ6rfbnkZKIkkVEnhLZb4X5ou1lYoU56L0evuJY7m111c26VnWTFS1i41wIPRn6zbpATZE6ddtm",
+	"VSsow2uUAr4ExZNaLJB0LxKJcKaUALW9rFFem+oxjhyLHWd6JodayKxJLErIJXT3jvo8lOJcE4MaB7ml",
+	"3AHjRu0tdqYts27ZW1rDqL3bT94bQi/G4e/+W3bkTAkbD/q7SxdPu2tpqIz6zQO1WRi1QimhF6cjVse+",
+	"10/TGQihjTSjjdQNosw3oyfXvG7QU4grxpNDamuOuLLtHaZlrDwRDgO0cIUsykbVHW2H0/RjUb2L/lEo",
+	"xWvcyiYgJKHlMTIj1J1Vno1eXGVeeKYHZGzG55KdGwtow1y9hZdGuykUCtcYrGbYOPQxSfve780ZTO13",
+	"1fH+hVa48NEZZufvcZGy5tHr08eXBzptdXe7kqHqM1QthIcttnH2KOFU8zEGSu17/TR9EsDvHY7u64p7",
+	"EceQyydvMF0WeFkyFTfjPLRDLioN4HtISH3rqAHXT3sB109at//xuMvZmuJoyOZiGGWcV0QAH8O89j0v",
+	"TVbh/BWnmMZDCaqf2roBAzXlfmeXZO1E4EjyhEpp8/sCuPdIsc5Ui8aONOekPA3MTXuKt/8EznY+BZyB",
+	"lCl8yj/aTrcaLBpKfG0O6nT71uIVWYKQB/CeAVWevsRrAtaxAkqI64cjDjGQS2sFSjBJ1yjRxCBME/0l",
+	"ZZLEyryxYPaAph3zBsprQDBnLAVMuwcFS5Jv3O6YpY5wo0OkKrPEDz9Mf/jBpznURrzbYSeamJOserTH",
+	"f1p631vjbfVVtrQxhKhx3jxorBi7qoIDa6QNChUrT+Qf7Dhdg/qcb07nLQPR8fT5bI9Nom4gGrp4Iwwm",
+	"5Vo3p/DM2kgW7dnrzoRpopqKAzjjnhrb/mb28jHTb+AQdGz8rAVMz5S4hrVhH3C86mMWbUImSvnkgKjC",
+	"4SpAbWcAbu9R2/C3pLtvWnRE2HhLvD9GT+8xdthN7fsAIXle6/3L8jdrxbUNKW1Of+EWYYrKCEL1PSuk",
+	"dvSVUZcaIrCoPOQ7Rg95Ah49m/VmJWFgVOHViqVuXquYn2oSbXwhkkwqQGO8FVN6v0MOa4rDppDDBlv0",
+	"MLlb8f2MgoPCrNtd9ka6U/gqz5XvjHEPV+vvnWioR1GOl2CXm5nFTrEwXw+f5HJkTTp2nEYzpr28SO19",
+	"aytL+pScHt+LEjd9ykgGmZ82iuhG58h1NNlRlap7RAboXYMM9eotH8OVvpDNrKFpGWSZry+t66am69XW",
+	"ooe/lFVY7GEWHiSgjc7up3SaMe0kmp7RDJu/HXmxxwmxo2vBy2PbPAa9bvM7TJd5V8gNyUt3yjS1Ye7E",
+	"OVtDEsYHMnbR0xPwt+0sud0+vT2EeTferkfEdeOkenqxoVGtYLOtJDVCvEZ0VsZOdVoeGva0XzBTI9R8",
+	"wdKUXQlv1NLWGelDlk2Q0Qw6coOoG9I7HFdbp9qK1xckqme7mHkeJDo1bLgv+XxtgDJH7PZa/04SqgJ4",
+	"3dIleB05+4fXlzJFVcc2ZZS6N9VfF1NpGyFc5a5UTe0Y9Nrxnyjio23JhnZmXoHEJBV7OEh2XIJWR+qr",
+	"d/N/el0nA+h1zdyYy3Kw+293OCXiPDa5AFD3XZdG0eHewfE+P0Kt6aXsUhtz7dC1QeaKEymBTndTWnZx",
+	"4jUmoE76hvWvBwGNZNrb1T5GujY2DHe3QMiWM2FHRae/v5txU5bS0v3aJDwNYv4+D+Yb/X3HkUGEzaCD",
+	"BBHqtahtSrLptlZl3NQ3fqpTbbAwgee72pXYZTn4nmyKeueqefsSWnCWNVSLcUoh18ufDKFCywWm4kqX",
+	"F1C/kjJZcQ9C2AavtE72jBAkRCqx5eiSwJUvc8fAqSzEDil22pGt/ksgTglV/2V4PdcZs3aWd4TBlsfX",
+	"UmDHVDJsg9tbM99ghg2S+1DQUEf2D4XBPuVhCwaavraDn8k83Tsj7x7HZtxWmvQ+iFlQSVKjFiukrDTi",
+	"g2UmdiP8fNzgiSq4sc2PgvRWcHF+A+WKnaL3TJDKa+CZRvUYyhiFtT+5+MrnNPmovR5dT6V1TE79dlnS",
+	"25L6zeM2nh7Eu77NY17F1OiPesRmer1LzNk8hWzrura8TBR9+MtL9ONPsx9RblpAiUam6aTNA+Z7b3WO",
+	"FBu9GIkcYrIgsRFStYyxNRyDd9KAc+aLYvkLgTQpCxNpdcbKgt13dwumMSPSjb1WPfkOxoQK6SSizbRy",
+	"1Qr5qlPUk5yun/NmPpy+arUW2bTmLwWTkBhR4NqPSGz+q12TQXv/Xz9+fI/Mj42cEW63Gn/thNIW03b+",
+	"6yTfIsswL/21F4TqFNxN1JkvOs1JtZOiTx9OEUmASrJYu6G2Gq2CNgtOT4w7kJ/YH08ok08WrKA7KC36",
+	"18p+VOorlp1rDLBBrGosNAw4F+rF7jz87ezdW5TX+Es/5ywvjtnmLPGjXwZC4KXfKM8L3zr+vZQepB6o",
+	"9Tnn7AK2T6MZiG2+osA3Z0anSaqqHo+pGsBWnburaisNRivbe6cXubarpnW7XVXA0uhbnU443w1GnKlz",
+	"2/mIsDPJzvfdTn1d+xreGJDm5uidUizFamw+QXcsm1Pi5hCzzEYjGp1WZYGtUabSvVcq9atkhOaJVFu4",
+	"zANUPaMGdvjcuuGxWJ/yJJTLCOUyQrmMUC4jlMsI5TI2e5hNyrP7VPMw97gkbeBhRqT0HcoeVrWM9k45",
+	"rvDvuPIXhe47uYclMAZUvTAT6EKc91A0hvjkW2Vux6TK/+gS5UfXgrBpPi4Em4hG4PWBKkXouOIR0cpd",
+	"+60oslb1VhuiXJ0rjmf+KM8xGRR9ocb9PPS9p+2bWai7EFg6so7MRn/XdkfX0CIcpkHTWnfcmpj+Ad/b",
+	"tPHvKWV7o0HnsFv94FTtLuuoKYS44ESuz9SOYNOHAHPgLwq5Kuv7q5fM1xXtKylzQwahC+YxrzubOv7X",
+	"f/3rf0CgBKMX709RjjlGDM1xfPEEaKK+xnlqHvtPZqB4avKNhOTFv/47wfpQRSUght6++Qf6Gys4hbV6",
+	"8wOLL0AKMAdEi18T18YkmlwCF4aep9PZdKZdMDlQnBNl/9FfRRNl0NRDP7JBMHoaxZG1RIijb7qAw7V6",
+	"ZGncRGoUGUidyvrHtwlRPahmnKP5pKz5UC2SccOavdcXPfjZ+Ztt/sbx7Jn6EzMqwZiucK5nSlF39E9b",
+	"TLZqD6hS7//Qjl+12k0H8HWnYNLEbm2oVNeuo8mz2WxDp9aO/f+6ne/gz5h4SDg1lW6d6dhQ8PNtUuBu",
+	"q1BdP7/twUvgFKdIAFf5R2B8PTWxnJz88TmaWDeGIjbFJKsZyaoIExWAr2saK7+fiaXRIqHRxKSSi8ln",
+	"1XaLyevRSYHRA6PfE0Y3PKrsMTUj867cXudpL9PrcJTA7YHb7xu3a8PQrmyuudjyN6Fz5Vc+ItLEgedM",
+	"eAzav4MWI3vMVe05Ik0pCefU5pCnBEyRfHfwZXyJKfkTeGU7XbT8OMLYwJT/V53jdUv4ylFuy9K4PqrX",
+	"kBVO0Yl/wdT5DNVbRKKC1p/VLvyWzHqKACRIQMxBIgESEYrev3nx9u3rD+enb3999+ntq/Oz1y8/vP44",
+	"1T71yclkBTgBXon8vz8xGcT8yamZ5CdnurnJVqnXzP8rS9Yt9rNe6CO+iH86Pm4yXXmOmROKdWJHu+nr",
+	"NvpcB4QxFDy9TQo+UVzIFePqQgvT/Q+32f1fGJ+TJAFq+n52m32/ZQpECpo8CHR9z1ms4ApTRD6evtfg",
+	"pouPWrhtRkZv0CGaqnLt02ly7RQMo0XIeLWbOtFoZKNasS2MYCPgbJb/jUU8eu/IuLZIFIAnAM+dAE/Q",
+	"KD06pGgdmRi1MLcPtpmiYtZYnIKEOwG3ADQBaL5zDaeU9jPJcnU6S9S5qCnxOqtZC2x5K505qBG+DQii",
+	"3e0gNynbs4NpLxtKMwahD0L/wIT+I5hEVl0LFG+oBDpW+vPiHkh/2NmDkH/POzvQZOCOjvASk72MFy7Q",
+	"5TFZLjbGAQUrakCiYMwwxoxnx8e32XUtpc8kpN5Xg8oK06XJJFDwaLMDDm1a4eIyf2zI25tIGVA3oG5A",
+	"3YC6G1D3ha5Z1A4Q6GCvZLthrzBXgrVM1/cBce5U3u/hwr9hS2Qq3CtgvGQX0IiitguJdPyVL7IxKkNe",
+	"bsQN6rt3b6ft7OlN0RDsmMO2uIC320MllBASk45vaqAjvF3yFM6WtcT8DoR2Ao2Kv1BvqEwrAaiWvIDU",
+	"5GBChanDI+GrLAOzvhTA15Ue3Ep56A3Fijb0r+viAE2UMs84wgupNx8iUIIlRBvKwfooUoF7E68CbivB",
+	"DiZNJ3JY4spLnEZSJ9kBaCvLhCqKymg8SAzRfV236ot21qp2/VQnZNFkMbtLjlR1QAmZTsfjIAub0enp",
+	"MyUZacboJQYXJyfPZ/Xs6NlsY20B30zk+Euh9ybBuCUDEoQFqtW3KwtwcbgkrBBlYT4fseaVybA44sP5",
+	"yTqVC8Ou8nAVKSJkmZBmM6QFaIww5Q7wBQitTKOm0diFE9+GJlXPVrwTNapxF2ng9qBD7WMpdKoShSst",
+	"dT0x+i7rRN9Jez00msq8FpytwdgWnK2HktxXWv5cILius6+KUciVDquSEscrc/2fvReys1Punjd2E9J7",
+	"cAWwfQdFEOkg0g9MpH8D6eTZlEoVPTpuccuSe1MRDoNV6bDnB4AIDrbv9bBiQMPjPes/qRw1i7EtfZXy",
+	"azXZMAe05KzIzQXb7m6ueMUZZSlbkhiniPEEeKRVLv2veVbbMFWNQULN/dQJXk/RK7yu3YDc7IgpoySh",
+	"piamvd7NfDrXJXPOE/UyEUihlSdd+GYA3287rZHuMTev78ja3KGqaWde34mZGddL/GFbPZgybauHBK2h",
+	"1ytQ5DHLCF02iCgtv7YA1naz86lhIndHnGhWbuzwYuS4tlYHXfEjoQm76iO1y6h7Eh1s5bd3VPJc8hqU",
+	"oaAMPdzTUgVmda2gXra05hm4m130H2orkAwlrLp/pyy460q/mjLk4kSXe0V4roBariCrbqkwohiZO8RU",
+	"KTxEpCmojNGz2c99uFMVk/Uj5UR1OInKE5P9aLqYfO6O76ZS9rU5ul21/k68LJ2CwAEiA0SG8+KDdG7V",
+	"N4l17xax8fR4FNv5FsNqrt1v27kDOcdMQSEMaPdgFcIP+h43E3rr+BnNQV5BU9/S5oKFx4w0AA2+2f/X",
+	"d+MNj7wNVzQFV3sAlgAsN+Rq36ZH3K6D7kahYM9jrLkzDRH5aA+s/mvWdjqwzm6MiKDCBaQNB9aH7uAc",
+	"dWCdm7vGH9Uh9TdwV6iH42nAtgftr1DqkhPR6gyqQwmk9tCay29dBpW+N7isFu4wwF431ocA+mK0xyb/",
+	"9nK5IP1B+kN53bHAo4WoDjtLTKiQiEjh7jAUkYpnKE907gboHvh5RKGozesrQzBqQLT7hWjhwOSvOOiH",
+	"tt3VpRinQBPMpyTeECFayBVQqYaqI+PkSvfaKC+gTVzlT/orQ4/rAYlaLV8kWPUDznOBYkxRztLU5uvc",
+	"TZTKxy1kRyofN6lfct+Zh/7YSnP71D6RbxK+ynLFmkzmuWkmwGmA0wd08Uvz+tI/Pl9H364bFU5ef9X+",
+	"zdqZUSlu9aByc1HMSye5C5LCjmHyTqiO6tIe0nwDLARYuOughqqemFeRKC90T1Pg+n68/vyYWw6C/XyT",
+	"QZp2MsIFCUHOH4Oc21jF4XIe6UvhcKzT/um6ykOxeU67bP7V/W93azMOe33AgGAjvsMr2OqHCwE0sZc2",
+	"NO7J3Q1TSqvLLYNKSCy8o8TC13bBgxIWAPgRpBQ6/Or3fN36Seqm0u2s5N5ptl1JQwCPAB7BH3aYjDeL",
+	"V7s7w9yvR9/sf/cpu6UkKRw6A2wFnedmklsGQ4Y+GRpRegwakb74qXHz3s1pRQFKApSE7I3Ho3wZ7ECC",
+	"ZcAoKAOTq7q15aYnP6QezYv0oo6rrVAdXQsfo7+dvXuLUiJM9FOScBACdLV8jF6e/R0VecpwLWgJlEEv",
+	"QgqJtZkvZTFOAcUsLTIqIv1MioVE8oohpnvD6RS9KFvGXD2d5ZhDgsiSMoXIKMbCtKdr3xGBuM7GhgQx",
+	"Gt9e9bob2hZ+LdILs7zVfhDZcCRx2WynJHFOKOZrD5G3mhxYJz0crsPWEraWh7u1ZNrBCyxPG9sLwrJE",
+	"2d23mZTQi+Ce+V7cM2/UaocdIOwAj8A3o5GrDnb6i0fmlVECe6cuGUNAAIwAGMEfcxh/jIIpH2z1KWdH",
+	"39Sf++SDMfQEB0zAqaDY3IwDpg8j7kNRsUNJ/03lcA9WmQLyBOQJGtI9Knk1QENiVxS4BsSHX4Lio637",
+	"806NSazCpWgBxYJrILgGdgLQvyqnq4VPVTJVWc4x1RVYUc0JMMw30HgiuAi+n6uhapFPwVsQNoXH4C2o",
+	"Y9l4FDz6Vvt0n0xyDbKCZS6ASNAsH0E+7gfI2KU6FtfEGy04yzzVPTxAVggNRVXs4M35KT8J4HfqpzQE",
+	"DFNVjmfHNwttH12kJZpDyuhSGLVc3YjACipRrElPdB0HpSJqa4ZSbVmaKB1ebURT9EIbREwx4DjFJBPl",
+	"7Qm1y1rRFRZIAJUuJkh3PL0vaBoOjPes0Nhnr4dSQYZjzzq6GCj5fL2tWtnn6/8dAE2jE8dgIQEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
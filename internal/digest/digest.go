@@ -0,0 +1,177 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"planner-go/internal/pgstore"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	// PollInterval is how long the scheduler sleeps between two looks for
+	// trips whose digests are due.
+	PollInterval time.Duration
+	// SendAt is the time of day, in the time zone of each trip, from which
+	// its digests are sent.
+	SendAt time.Duration
+	// Daily sends the participants of a trip the activities of the next day
+	// on each day before one of the trip.
+	Daily bool
+	// NoticeDays are how many days before a trip starts its participants are
+	// told so. Empty sends no notice.
+	NoticeDays []int32
+	// BatchSize is how many digests are queued at once.
+	BatchSize int32
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 5 * time.Minute,
+		SendAt:       18 * time.Hour,
+		Daily:        true,
+		NoticeDays:   []int32{7},
+		BatchSize:    50,
+	}
+}
+
+// LoadConfig reads the PLANNER_DIGEST_* variables over DefaultConfig. The time
+// of day is written as "18:00", the notice days are a comma-separated list
+// such as "7,1", and "off" turns off the daily digest or the notices.
+func LoadConfig() (Config, error) {
+	cfg := DefaultConfig()
+
+	if v := os.Getenv("PLANNER_DIGEST_POLL_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("digest: invalid PLANNER_DIGEST_POLL_INTERVAL: %w", err)
+		}
+		cfg.PollInterval = d
+	}
+
+	if v := os.Getenv("PLANNER_DIGEST_SEND_AT"); v != "" {
+		t, err := time.Parse("15:04", v)
+		if err != nil {
+			return Config{}, fmt.Errorf("digest: invalid PLANNER_DIGEST_SEND_AT: %w", err)
+		}
+		cfg.SendAt = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	switch v := os.Getenv("PLANNER_DIGEST_DAILY"); v {
+	case "":
+	case "on":
+		cfg.Daily = true
+	case "off":
+		cfg.Daily = false
+	default:
+		return Config{}, fmt.Errorf("digest: invalid PLANNER_DIGEST_DAILY: %q is neither on nor off", v)
+	}
+
+	if v := os.Getenv("PLANNER_DIGEST_NOTICE_DAYS"); v != "" {
+		cfg.NoticeDays = nil
+		if v != "off" {
+			for _, s := range strings.Split(v, ",") {
+				n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 32)
+				if err != nil {
+					return Config{}, fmt.Errorf("digest: invalid PLANNER_DIGEST_NOTICE_DAYS: %w", err)
+				}
+				cfg.NoticeDays = append(cfg.NoticeDays, int32(n))
+			}
+		}
+	}
+
+	return cfg, cfg.validate()
+}
+
+func (cfg Config) validate() error {
+	if cfg.PollInterval <= 0 {
+		return errors.New("digest: poll interval must be positive")
+	}
+	if cfg.SendAt < 0 || cfg.SendAt >= 24*time.Hour {
+		return errors.New("digest: send time must be within the day")
+	}
+	for _, n := range cfg.NoticeDays {
+		if n <= 0 {
+			return errors.New("digest: notice days must be positive")
+		}
+	}
+	return nil
+}
+
+// Scheduler sends the participants of confirmed trips the activities of the
+// next day and tells them how many days are left before the trip starts. What
+// it sends goes through the outbox along with the day it was sent on, so
+// restarts and other instances do not repeat it.
+type Scheduler struct {
+	pool   *pgxpool.Pool
+	store  *pgstore.Queries
+	logger *zap.Logger
+	cfg    Config
+}
+
+func NewScheduler(pool *pgxpool.Pool, logger *zap.Logger, cfg Config) *Scheduler {
+	return &Scheduler{pool, pgstore.New(pool), logger, cfg}
+}
+
+// Run looks for due digests until ctx is done. A look that is under way when
+// ctx is done is finished first.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		s.tick(context.WithoutCancel(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.cfg.PollInterval):
+		}
+	}
+}
+
+// tick queues the digests of the trips where it is already past SendAt. The
+// day of each trip is taken from its own time zone by the database.
+func (s *Scheduler) tick(ctx context.Context) {
+	sendAfter := pgtype.Time{Microseconds: s.cfg.SendAt.Microseconds(), Valid: true}
+
+	if s.cfg.Daily {
+		if err := s.drain(func() (int, error) {
+			return s.store.QueueDailyDigests(ctx, s.pool, pgstore.ClaimDailyDigestsParams{
+				SendAfter: sendAfter,
+				RowLimit:  s.cfg.BatchSize,
+			})
+		}); err != nil {
+			s.logger.Error("Failed to queue daily digests", zap.Error(err))
+		}
+	}
+
+	if len(s.cfg.NoticeDays) > 0 {
+		if err := s.drain(func() (int, error) {
+			return s.store.QueueTripCountdowns(ctx, s.pool, pgstore.ClaimTripCountdownsParams{
+				SendAfter: sendAfter,
+				Days:      s.cfg.NoticeDays,
+				RowLimit:  s.cfg.BatchSize,
+			})
+		}); err != nil {
+			s.logger.Error("Failed to queue trip countdowns", zap.Error(err))
+		}
+	}
+}
+
+// drain calls batch until it handles less than a full batch.
+func (s *Scheduler) drain(batch func() (int, error)) error {
+	for {
+		n, err := batch()
+		if err != nil {
+			return err
+		}
+		if n < int(s.cfg.BatchSize) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package exchange
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoRate is returned when no rate converts between two currencies on a
+// day.
+var ErrNoRate = errors.New("exchange: no rate between the currencies")
+
+// Provider converts amounts between currencies. Implementations may read
+// their rates from anywhere, but must answer with exact numbers.
+type Provider interface {
+	// Rate returns how much one unit of from is worth in to, using the latest
+	// rate known on the given day.
+	Rate(ctx context.Context, from, to string, on time.Time) (*big.Rat, error)
+}
+
+// Rate is the worth of one unit of Base in Quote from ValidOn onwards.
+type Rate struct {
+	Base    string
+	Quote   string
+	ValidOn time.Time
+	Value   *big.Rat
+}
+
+// ParseCSV reads rates from rows of date, base, quote and rate, such as
+// "2024-07-01,EUR,USD,1.0736". An optional header row and rows starting with
+// # are skipped.
+func ParseCSV(r io.Reader) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	var rates []Rate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rates, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("exchange: failed to read rates: %w", err)
+		}
+		if line == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+
+		validOn, err := time.Parse(time.DateOnly, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("exchange: invalid date on line %d: %w", line, err)
+		}
+
+		value, ok := new(big.Rat).SetString(record[3])
+		if !ok || value.Sign() <= 0 {
+			return nil, fmt.Errorf("exchange: invalid rate on line %d: %q", line, record[3])
+		}
+
+		rates = append(rates, Rate{
+			Base:    strings.ToUpper(record[1]),
+			Quote:   strings.ToUpper(record[2]),
+			ValidOn: validOn,
+			Value:   value,
+		})
+	}
+}
+
+// LoadFile reads the rates of a CSV file in the format of ParseCSV.
+func LoadFile(path string) ([]Rate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("exchange: failed to open rates: %w", err)
+	}
+	defer f.Close()
+
+	return ParseCSV(f)
+}
+
+type pair struct{ base, quote string }
+
+// Table is a Provider that keeps its rates in memory, such as those of a file
+// loaded offline. Besides the rates it holds, it converts through their
+// inverses and through one currency both sides have a rate with, as sources
+// often quote every currency against a single one.
+type Table struct {
+	// rates of each pair, sorted by ValidOn
+	rates map[pair][]Rate
+}
+
+// NewTable returns a Table holding rates.
+func NewTable(rates []Rate) *Table {
+	t := &Table{rates: make(map[pair][]Rate)}
+	for _, rate := range rates {
+		p := pair{rate.Base, rate.Quote}
+		t.rates[p] = append(t.rates[p], rate)
+	}
+	for _, list := range t.rates {
+		sort.SliceStable(list, func(a, b int) bool {
+			return list[a].ValidOn.Before(list[b].ValidOn)
+		})
+	}
+	return t
+}
+
+// Rate implements Provider.
+func (t *Table) Rate(_ context.Context, from, to string, on time.Time) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	if rate, ok := t.pairRate(from, to, on); ok {
+		return rate, nil
+	}
+
+	// the currencies both sides can be converted to, in a stable order
+	var through []string
+	for p := range t.rates {
+		through = append(through, p.base, p.quote)
+	}
+	sort.Strings(through)
+
+	for _, c := range through {
+		if c == from || c == to {
+			continue
+		}
+		first, ok := t.pairRate(from, c, on)
+		if !ok {
+			continue
+		}
+		second, ok := t.pairRate(c, to, on)
+		if !ok {
+			continue
+		}
+		return first.Mul(first, second), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s to %s on %s", ErrNoRate, from, to, on.Format(time.DateOnly))
+}
+
+// pairRate returns the latest rate from base to quote on the day, or the
+// inverse of the one from quote to base.
+func (t *Table) pairRate(base, quote string, on time.Time) (*big.Rat, bool) {
+	if rate, ok := latest(t.rates[pair{base, quote}], on); ok {
+		return new(big.Rat).Set(rate.Value), true
+	}
+	if rate, ok := latest(t.rates[pair{quote, base}], on); ok {
+		return new(big.Rat).Inv(rate.Value), true
+	}
+	return nil, false
+}
+
+func latest(rates []Rate, on time.Time) (Rate, bool) {
+	i := sort.Search(len(rates), func(i int) bool {
+		return rates[i].ValidOn.After(on)
+	})
+	if i == 0 {
+		return Rate{}, false
+	}
+	return rates[i-1], true
+}
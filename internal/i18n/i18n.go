@@ -0,0 +1,147 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Locale is the BCP 47 tag of one of the Supported languages.
+type Locale string
+
+const (
+	English    Locale = "en"
+	Portuguese Locale = "pt-BR"
+	Spanish    Locale = "es"
+)
+
+// Default is used when nothing better is known about the reader.
+const Default = English
+
+// Supported lists the locales with a catalog, Default first.
+var Supported = []Locale{English, Portuguese, Spanish}
+
+var matcher = language.NewMatcher([]language.Tag{
+	language.English,
+	language.BrazilianPortuguese,
+	language.Spanish,
+})
+
+// catalogs maps each locale to its translations, keyed by the English text.
+// English has no catalog, its keys are already the messages.
+var catalogs = map[Locale]map[string]string{}
+
+//go:embed catalog
+var embedded embed.FS
+
+func init() {
+	for _, locale := range Supported[1:] {
+		b, err := embedded.ReadFile("catalog/" + string(locale) + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read %s catalog: %v", locale, err))
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(b, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: failed to parse %s catalog: %v", locale, err))
+		}
+		catalogs[locale] = messages
+	}
+}
+
+// Parse returns the supported locale closest to tag, such as pt-BR for "pt",
+// or Default when there is none.
+func Parse(tag string) Locale {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return Default
+	}
+
+	_, i, confidence := matcher.Match(t)
+	if confidence == language.No {
+		return Default
+	}
+	return Supported[i]
+}
+
+// Negotiate returns the supported locale that best matches an Accept-Language
+// header, or Default when none does.
+func Negotiate(acceptLanguage string) Locale {
+	_, i := language.MatchStrings(matcher, acceptLanguage)
+	return Supported[i]
+}
+
+// Sprintf translates the English format key and formats it with args like
+// fmt.Sprintf. Keys missing from the catalog are used as they are.
+func (l Locale) Sprintf(key string, args ...any) string {
+	format := key
+	if msg, ok := catalogs[l][key]; ok {
+		format = msg
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// layouts are the time layouts of a date and of a date with its time. The
+// English month name they produce is replaced by the translated one.
+var layouts = map[Locale][2]string{
+	English:    {"January 2, 2006", "January 2, 2006, 3:04 PM MST"},
+	Portuguese: {"2 de January de 2006", "2 de January de 2006, 15:04 MST"},
+	Spanish:    {"2 de January de 2006", "2 de January de 2006, 15:04 MST"},
+}
+
+// Date formats the day of t the way it is written in l.
+func (l Locale) Date(t time.Time) string {
+	return l.format(t, 0)
+}
+
+// DateTime formats the day and time of t the way they are written in l.
+func (l Locale) DateTime(t time.Time) string {
+	return l.format(t, 1)
+}
+
+func (l Locale) format(t time.Time, layout int) string {
+	ls, ok := layouts[l]
+	if !ok {
+		ls = layouts[Default]
+	}
+
+	month := t.Month().String()
+	return strings.Replace(t.Format(ls[layout]), month, l.Sprintf(month), 1)
+}
+
+type contextKey struct{}
+
+// WithLocale returns a copy of ctx that carries l.
+func WithLocale(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the locale set by Middleware, or Default.
+func FromContext(ctx context.Context) Locale {
+	if l, ok := ctx.Value(contextKey{}).(Locale); ok {
+		return l
+	}
+	return Default
+}
+
+// Middleware negotiates the locale of each request from its Accept-Language
+// header and stores it in the request context.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := Negotiate(r.Header.Get("Accept-Language"))
+
+		w.Header().Set("Content-Language", string(locale))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), locale)))
+	})
+}
@@ -0,0 +1,249 @@
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineLength is the limit of a content line in octets, not counting the
+// line break, after which it must be folded (RFC 5545, section 3.1).
+const maxLineLength = 75
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+// ProdID identifies the calendars made by this application.
+const ProdID = "-//plann.er//planner-go//EN"
+
+// Methods of the iTIP messages (RFC 5546) a calendar can be.
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+	MethodReply   = "REPLY"
+)
+
+// Participation statuses of an attendee.
+const (
+	PartStatNeedsAction = "NEEDS-ACTION"
+	PartStatAccepted    = "ACCEPTED"
+	PartStatDeclined    = "DECLINED"
+	PartStatTentative   = "TENTATIVE"
+)
+
+// Calendar is a VCALENDAR object, published for calendar apps to read unless
+// Method says otherwise.
+type Calendar struct {
+	// ProdID identifies the product that created the calendar, usually the
+	// constant of the same name.
+	ProdID string
+	// Method is the iTIP method of the calendar. It defaults to MethodPublish.
+	Method string
+	Name   string
+	// RefreshInterval hints how often subscribers should poll the calendar.
+	// Zero leaves it to them.
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Event is a VEVENT. Events are either all-day, spanning the days from Start
+// to End inclusive as they read in their location, or timed. Timed events with
+// a zero End only have a start.
+type Event struct {
+	// UID identifies the event across exports, so it must not change when the
+	// event does.
+	UID         string
+	Stamp       time.Time
+	AllDay      bool
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	// Geo is the position of Location, if it is known.
+	Geo *Geo
+	URL string
+	// Sequence is the revision of the event, which must grow each time an
+	// invitation for it is sent again with changes.
+	Sequence  int
+	Organizer *Address
+	Attendees []Attendee
+}
+
+// Geo is a position on Earth, in degrees.
+type Geo struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Address is a calendar user, such as the organizer of an event.
+type Address struct {
+	Name  string
+	Email string
+}
+
+// Attendee is a calendar user invited to an event.
+type Attendee struct {
+	Address
+	PartStat string
+	// RSVP asks the attendee to reply to the invitation.
+	RSVP bool
+}
+
+// Marshal encodes c as an iCalendar stream.
+func (c Calendar) Marshal() []byte {
+	var buf bytes.Buffer
+	w := writer{&buf}
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProdID)
+	w.line("CALSCALE", "GREGORIAN")
+	if c.Method == "" {
+		c.Method = MethodPublish
+	}
+	w.line("METHOD", c.Method)
+	if c.Name != "" {
+		w.line("NAME", escape(c.Name))
+		w.line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", duration(c.RefreshInterval))
+		w.line("X-PUBLISHED-TTL", duration(c.RefreshInterval))
+	}
+
+	for _, e := range c.Events {
+		e.marshal(w)
+	}
+
+	w.line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+func (e Event) marshal(w writer) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.UID)
+	w.line("DTSTAMP", e.Stamp.UTC().Format(dateTimeLayout)+"Z")
+
+	if e.AllDay {
+		// the end of an all-day event is the day after its last one
+		w.line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+		w.line("DTEND;VALUE=DATE", e.End.AddDate(0, 0, 1).Format(dateLayout))
+	} else {
+		w.line("DTSTART", dateTime(e.Start))
+		if !e.End.IsZero() {
+			w.line("DTEND", dateTime(e.End))
+		}
+	}
+
+	w.line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", escape(e.Description))
+	}
+	if e.Location != "" {
+		w.line("LOCATION", escape(e.Location))
+	}
+	if e.Geo != nil {
+		w.line("GEO", strconv.FormatFloat(e.Geo.Latitude, 'f', -1, 64)+";"+strconv.FormatFloat(e.Geo.Longitude, 'f', -1, 64))
+	}
+	if e.URL != "" {
+		w.line("URL", e.URL)
+	}
+	if e.Sequence > 0 {
+		w.line("SEQUENCE", strconv.Itoa(e.Sequence))
+	}
+	if e.Organizer != nil {
+		w.line("ORGANIZER"+e.Organizer.params(), "mailto:"+e.Organizer.Email)
+	}
+	for _, a := range e.Attendees {
+		params := a.params() + ";ROLE=REQ-PARTICIPANT"
+		if a.PartStat != "" {
+			params += ";PARTSTAT=" + a.PartStat
+		}
+		if a.RSVP {
+			params += ";RSVP=TRUE"
+		}
+		w.line("ATTENDEE"+params, "mailto:"+a.Email)
+	}
+	w.line("END", "VEVENT")
+}
+
+// params returns the CN parameter of a, if it has a name. Parameter values
+// cannot hold double quotes, so those are dropped.
+func (a Address) params() string {
+	if a.Name == "" {
+		return ""
+	}
+	return `;CN="` + strings.ReplaceAll(a.Name, `"`, "") + `"`
+}
+
+// dateTime formats t as an instant in UTC, which calendar apps show in the
+// zone of the user.
+func dateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout) + "Z"
+}
+
+// duration formats d as a duration value of whole seconds, such as PT1H.
+func duration(d time.Duration) string {
+	var b strings.Builder
+	b.WriteString("PT")
+
+	s := int64(d / time.Second)
+	for _, unit := range []struct {
+		secs   int64
+		suffix byte
+	}{{3600, 'H'}, {60, 'M'}, {1, 'S'}} {
+		if n := s / unit.secs; n > 0 {
+			b.WriteString(strconv.FormatInt(n, 10))
+			b.WriteByte(unit.suffix)
+			s -= n * unit.secs
+		}
+	}
+
+	if b.Len() == 2 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escape escapes a TEXT value (RFC 5545, section 3.3.11).
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+type writer struct {
+	buf *bytes.Buffer
+}
+
+// line writes a content line, folding it into several physical lines of at
+// most maxLineLength octets without splitting a UTF-8 sequence.
+func (w writer) line(name, value string) {
+	line := name + ":" + value
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space, which counts towards the limit
+		limit = maxLineLength - 1
+	}
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
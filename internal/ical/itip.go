@@ -0,0 +1,101 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// uidDomain ends the UIDs of the events made from our rows, which makes them
+// unique across the calendars of a user.
+const uidDomain = "plann.er"
+
+// UID returns the UID of the event that stands for the row id of a kind of
+// record, such as "trip".
+func UID(kind string, id uuid.UUID) string {
+	return kind + "-" + id.String() + "@" + uidDomain
+}
+
+// ParseUID is the inverse of UID. It fails for events that were not made by
+// us.
+func ParseUID(uid string) (kind string, id uuid.UUID, ok bool) {
+	local, found := strings.CutSuffix(uid, "@"+uidDomain)
+	if !found {
+		return "", uuid.UUID{}, false
+	}
+
+	// the id is the last 36 characters, after the dash that ends the kind
+	i := len(local) - 36 - 1
+	if i < 1 || local[i] != '-' {
+		return "", uuid.UUID{}, false
+	}
+
+	id, err := uuid.Parse(local[i+1:])
+	if err != nil {
+		return "", uuid.UUID{}, false
+	}
+	return local[:i], id, true
+}
+
+var ErrNotReply = errors.New("ical: calendar is not an iTIP reply")
+
+// Reply is the answer of an attendee to an invitation.
+type Reply struct {
+	UID      string
+	Attendee string
+	PartStat string
+}
+
+// ParseReply reads an iTIP REPLY (RFC 5546, section 3.2.3), which carries the
+// answer of a single attendee.
+func ParseReply(data []byte) (Reply, error) {
+	cal, err := Parse(data)
+	if err != nil {
+		return Reply{}, err
+	}
+
+	if cal.Name != "VCALENDAR" {
+		return Reply{}, fmt.Errorf("%w: unexpected %s component", ErrMalformed, cal.Name)
+	}
+
+	if method, _ := cal.Prop("METHOD"); !strings.EqualFold(method.Value, MethodReply) {
+		return Reply{}, ErrNotReply
+	}
+
+	events := cal.Children("VEVENT")
+	if len(events) == 0 {
+		return Reply{}, fmt.Errorf("%w: reply has no event", ErrMalformed)
+	}
+
+	uid, ok := events[0].Prop("UID")
+	if !ok {
+		return Reply{}, fmt.Errorf("%w: reply event has no UID", ErrMalformed)
+	}
+
+	attendees := events[0].PropsNamed("ATTENDEE")
+	if len(attendees) != 1 {
+		return Reply{}, fmt.Errorf("%w: reply must have a single attendee, it has %d", ErrMalformed, len(attendees))
+	}
+
+	email, ok := mailto(attendees[0].Value)
+	if !ok {
+		return Reply{}, fmt.Errorf("%w: attendee %q is not a mailto address", ErrMalformed, attendees[0].Value)
+	}
+
+	return Reply{
+		UID:      uid.Text(),
+		Attendee: email,
+		PartStat: strings.ToUpper(attendees[0].Params["PARTSTAT"]),
+	}, nil
+}
+
+// mailto returns the e-mail of a mailto URI, whatever the case of its scheme.
+func mailto(uri string) (string, bool) {
+	scheme, email, ok := strings.Cut(uri, ":")
+	if !ok || !strings.EqualFold(scheme, "mailto") || email == "" {
+		return "", false
+	}
+	return email, true
+}
@@ -0,0 +1,178 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrMalformed = errors.New("ical: malformed calendar")
+
+// Component is a parsed component, such as VCALENDAR or VEVENT, with the
+// properties and components nested in it.
+type Component struct {
+	Name       string
+	Props      []Property
+	Components []*Component
+}
+
+// Property is a parsed content line. Names are upper case and values are
+// kept as they were written, see Text for unescaping them.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Prop returns the first property called name.
+func (c *Component) Prop(name string) (Property, bool) {
+	for _, p := range c.Props {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+// PropsNamed returns every property called name.
+func (c *Component) PropsNamed(name string) []Property {
+	var props []Property
+	for _, p := range c.Props {
+		if p.Name == name {
+			props = append(props, p)
+		}
+	}
+	return props
+}
+
+// Children returns the components called name nested directly in c.
+func (c *Component) Children(name string) []*Component {
+	var children []*Component
+	for _, child := range c.Components {
+		if child.Name == name {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+var unescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+// Text returns the value of a TEXT property unescaped.
+func (p Property) Text() string {
+	return unescaper.Replace(p.Value)
+}
+
+// Parse reads the first component of an iCalendar stream, which is usually a
+// VCALENDAR. It is lenient about bare LF line breaks, which some mail clients
+// produce.
+func Parse(data []byte) (*Component, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	// unfold the lines that were split to fit the length limit
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+
+	var stack []*Component
+	for n, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrMalformed, n+1, err)
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(p.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrMalformed, n+1, p.Value)
+			}
+			if len(stack) == 1 {
+				return stack[0], nil
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: line %d: property outside of a component", ErrMalformed, n+1)
+			}
+			c := stack[len(stack)-1]
+			c.Props = append(c.Props, p)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: unterminated component", ErrMalformed)
+}
+
+// parseLine splits a content line into its name, parameters and value. The
+// value starts at the first colon that is not inside a quoted parameter.
+func parseLine(line string) (Property, error) {
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return Property{}, errors.New("missing colon")
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := splitParams(head)
+
+	p := Property{Name: strings.ToUpper(parts[0]), Value: value}
+	if p.Name == "" {
+		return Property{}, errors.New("missing property name")
+	}
+
+	for _, param := range parts[1:] {
+		name, v, ok := strings.Cut(param, "=")
+		if !ok {
+			return Property{}, fmt.Errorf("malformed parameter %q", param)
+		}
+		if p.Params == nil {
+			p.Params = make(map[string]string)
+		}
+		p.Params[strings.ToUpper(name)] = strings.Trim(v, `"`)
+	}
+
+	return p, nil
+}
+
+// splitParams splits the name and parameters of a content line on the
+// semicolons that are not inside quotes.
+func splitParams(head string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(head); i++ {
+		switch head[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, head[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, head[start:])
+}
@@ -0,0 +1,113 @@
+package ledger
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Split divides amount, in minor units, proportionally to weights. The cents
+// left over by rounding down go to the largest remainders, and to the earliest
+// weights on ties, so the parts always add up to amount. Weights must be
+// positive.
+func Split(amount int64, weights []int64) []int64 {
+	parts := make([]int64, len(weights))
+
+	var total int64
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return parts
+	}
+
+	remainders := make([]int64, len(weights))
+	var assigned int64
+	for i, w := range weights {
+		parts[i] = amount / total * w
+		// amount%total*w stays well within int64 for realistic amounts and shares
+		rest := amount % total * w
+		parts[i] += rest / total
+		remainders[i] = rest % total
+		assigned += parts[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+
+	for i := 0; assigned < amount; i++ {
+		parts[order[i%len(order)]]++
+		assigned++
+	}
+
+	return parts
+}
+
+// Transfer is a payment that settles part of the debts of a trip.
+type Transfer struct {
+	From   uuid.UUID
+	To     uuid.UUID
+	Amount int64
+}
+
+type balance struct {
+	id     uuid.UUID
+	amount int64
+}
+
+// Settle returns the transfers that bring every net balance to zero. Positive
+// balances are owed to the participant, negative ones are owed by them, and
+// they must add up to zero. The largest debtor always pays the largest
+// creditor, which takes at most one transfer less than the participants with
+// a balance. The result only depends on the balances, not on the map order.
+func Settle(balances map[uuid.UUID]int64) []Transfer {
+	var creditors, debtors []balance
+	for id, amount := range balances {
+		switch {
+		case amount > 0:
+			creditors = append(creditors, balance{id, amount})
+		case amount < 0:
+			debtors = append(debtors, balance{id, -amount})
+		}
+	}
+	sortBalances(creditors)
+	sortBalances(debtors)
+
+	var transfers []Transfer
+	for len(creditors) > 0 && len(debtors) > 0 {
+		amount := min(creditors[0].amount, debtors[0].amount)
+		transfers = append(transfers, Transfer{
+			From:   debtors[0].id,
+			To:     creditors[0].id,
+			Amount: amount,
+		})
+
+		creditors[0].amount -= amount
+		debtors[0].amount -= amount
+		if creditors[0].amount == 0 {
+			creditors = creditors[1:]
+		}
+		if debtors[0].amount == 0 {
+			debtors = debtors[1:]
+		}
+		sortBalances(creditors)
+		sortBalances(debtors)
+	}
+
+	return transfers
+}
+
+func sortBalances(list []balance) {
+	sort.Slice(list, func(a, b int) bool {
+		if list[a].amount != list[b].amount {
+			return list[a].amount > list[b].amount
+		}
+		return bytes.Compare(list[a].id[:], list[b].id[:]) < 0
+	})
+}
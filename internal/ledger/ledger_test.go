@@ -0,0 +1,118 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		weights []int64
+		want    []int64
+	}{
+		{"even", 900, []int64{1, 1, 1}, []int64{300, 300, 300}},
+		{"remainder to earliest on ties", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"two cents left", 200, []int64{1, 1, 1}, []int64{67, 67, 66}},
+		{"largest remainder first", 100, []int64{1, 2}, []int64{33, 67}},
+		{"largest remainder not first", 1000, []int64{3, 3, 1}, []int64{429, 428, 143}},
+		{"single share", 12345, []int64{7}, []int64{12345}},
+		{"less than a cent each", 2, []int64{1, 1, 1, 1}, []int64{1, 1, 0, 0}},
+		{"zero amount", 0, []int64{1, 2, 3}, []int64{0, 0, 0}},
+		{"large amount", 9_999_999_999, []int64{1, 1, 1}, []int64{3_333_333_333, 3_333_333_333, 3_333_333_333}},
+		{"no weights", 100, nil, []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split(tt.amount, tt.weights)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			var sum int64
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+					break
+				}
+				sum += got[i]
+			}
+			if len(got) > 0 && sum != tt.amount {
+				t.Errorf("parts add up to %d, want %d", sum, tt.amount)
+			}
+		})
+	}
+}
+
+func TestSettle(t *testing.T) {
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name      string
+		balances  []int64
+		transfers int
+	}{
+		{"nothing owed", []int64{0, 0, 0}, 0},
+		{"one debt", []int64{500, -500}, 1},
+		{"one creditor", []int64{900, -300, -300, -300}, 3},
+		{"one debtor", []int64{-1000, 250, 250, 500}, 3},
+		{"matching pairs", []int64{700, -700, 300, -300}, 2},
+		{"uneven", []int64{1001, -334, -333, -334, 0}, 3},
+		{"chain", []int64{100, 200, 300, -250, -350}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balances := make(map[uuid.UUID]int64)
+			var total int64
+			for i, amount := range tt.balances {
+				balances[ids[i]] = amount
+				total += amount
+			}
+			if total != 0 {
+				t.Fatalf("balances add up to %d, want 0", total)
+			}
+
+			transfers := Settle(balances)
+			if len(transfers) != tt.transfers {
+				t.Errorf("got %d transfers, want %d", len(transfers), tt.transfers)
+			}
+
+			left := make(map[uuid.UUID]int64, len(balances))
+			for id, amount := range balances {
+				left[id] = amount
+			}
+			for _, transfer := range transfers {
+				if transfer.Amount <= 0 {
+					t.Errorf("transfer of %d from %s to %s", transfer.Amount, transfer.From, transfer.To)
+				}
+				left[transfer.From] += transfer.Amount
+				left[transfer.To] -= transfer.Amount
+			}
+			for id, amount := range left {
+				if amount != 0 {
+					t.Errorf("%s is left with %d, want 0", id, amount)
+				}
+			}
+
+			// the transfers do not depend on the order of the map
+			for range 10 {
+				again := Settle(balances)
+				if len(again) != len(transfers) {
+					t.Fatalf("got %d transfers on another run, want %d", len(again), len(transfers))
+				}
+				for i := range again {
+					if again[i] != transfers[i] {
+						t.Fatalf("transfer %d: got %+v on another run, want %+v", i, again[i], transfers[i])
+					}
+				}
+			}
+		})
+	}
+}
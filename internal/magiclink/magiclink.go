@@ -0,0 +1,85 @@
+package magiclink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purposes a token can be issued for. The purpose is part of the signature, so
+// a token issued to confirm a participant cannot be used to confirm a trip.
+const (
+	PurposeTrip        = "trip"
+	PurposeParticipant = "participant"
+	PurposeAccount     = "account"
+)
+
+var (
+	ErrInvalidToken = errors.New("magiclink: invalid token")
+	ErrExpiredToken = errors.New("magiclink: expired token")
+)
+
+// Signer issues and verifies HMAC-signed tokens that point to a row in the
+// confirmation_tokens table. The signature only proves the token was issued by
+// us and is not expired; single use is enforced by the database.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key []byte) Signer {
+	return Signer{key}
+}
+
+// Sign returns an URL-safe token for the confirmation token id.
+func (s Signer) Sign(purpose string, id uuid.UUID, expiresAt time.Time) string {
+	payload := make([]byte, 0, 24)
+	payload = append(payload, id[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(expiresAt.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(s.mac(purpose, payload))
+}
+
+// Verify checks the signature and expiry of token and returns the
+// confirmation token id it carries.
+func (s Signer) Verify(purpose, token string, now time.Time) (uuid.UUID, error) {
+	encPayload, encSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.UUID{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encPayload)
+	if err != nil || len(payload) != 24 {
+		return uuid.UUID{}, ErrInvalidToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(encSig)
+	if err != nil || !hmac.Equal(sig, s.mac(purpose, payload)) {
+		return uuid.UUID{}, ErrInvalidToken
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if !now.Before(expiresAt) {
+		return uuid.UUID{}, ErrExpiredToken
+	}
+
+	id, err := uuid.FromBytes(payload[:16])
+	if err != nil {
+		return uuid.UUID{}, ErrInvalidToken
+	}
+	return id, nil
+}
+
+func (s Signer) mac(purpose string, payload []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(purpose))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return h.Sum(nil)
+}
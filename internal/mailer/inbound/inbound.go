@@ -0,0 +1,93 @@
+package inbound
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"planner-go/internal/ical"
+	"strings"
+)
+
+// maxDepth bounds how deep multipart bodies are searched for a calendar.
+const maxDepth = 5
+
+var ErrNoCalendar = errors.New("inbound: message has no calendar part")
+
+// CalendarReply reads a raw e-mail, as received by the mail server, and
+// returns the address of its sender along with the iTIP reply it carries.
+func CalendarReply(r io.Reader) (string, ical.Reply, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return "", ical.Reply{}, fmt.Errorf("inbound: failed to read message: %w", err)
+	}
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return "", ical.Reply{}, fmt.Errorf("inbound: failed to parse sender: %w", err)
+	}
+
+	data, err := findCalendar(textproto.MIMEHeader(msg.Header), msg.Body, 0)
+	if err != nil {
+		return "", ical.Reply{}, err
+	}
+
+	reply, err := ical.ParseReply(data)
+	if err != nil {
+		return "", ical.Reply{}, err
+	}
+
+	return from.Address, reply, nil
+}
+
+// findCalendar returns the content of the first calendar part of a body,
+// looking into nested multipart bodies.
+func findCalendar(header textproto.MIMEHeader, body io.Reader, depth int) ([]byte, error) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return nil, ErrNoCalendar
+	}
+
+	switch {
+	case mediaType == "text/calendar" || mediaType == "application/ics":
+		data, err := io.ReadAll(decode(header.Get("Content-Transfer-Encoding"), body))
+		if err != nil {
+			return nil, fmt.Errorf("inbound: failed to read calendar part: %w", err)
+		}
+		return data, nil
+
+	case strings.HasPrefix(mediaType, "multipart/") && depth < maxDepth:
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil, ErrNoCalendar
+			}
+			if err != nil {
+				return nil, fmt.Errorf("inbound: failed to read multipart body: %w", err)
+			}
+
+			data, err := findCalendar(part.Header, part, depth+1)
+			if !errors.Is(err, ErrNoCalendar) {
+				return data, err
+			}
+		}
+	}
+
+	return nil, ErrNoCalendar
+}
+
+func decode(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
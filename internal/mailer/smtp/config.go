@@ -0,0 +1,209 @@
+package smtp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/wneessen/go-mail"
+)
+
+// Config describes how to reach the SMTP server. Fields left out take the
+// matching value of DefaultConfig, and so do empty or zero ones, except for
+// PoolSize.
+type Config struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// TLS is one of "none", "opportunistic", "mandatory" (STARTTLS) or
+	// "implicit" (TLS from the first byte, usually on port 465).
+	TLS string `json:"tls"`
+	// Auth is one of "none", "plain", "login" or "cram-md5".
+	Auth     string `json:"auth"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+	// PoolSize is how many idle connections are kept open for reuse. 0 turns
+	// the pool off, and each send then dials a connection of its own.
+	PoolSize int `json:"pool_size"`
+	// IdleTimeout is how long an idle connection is kept before it is closed.
+	IdleTimeout Duration `json:"idle_timeout"`
+	// Timeout bounds dialing and each command sent to the server.
+	Timeout Duration `json:"timeout"`
+}
+
+// Duration is a time.Duration read from strings such as "30s".
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// DefaultConfig talks to the Mailpit container of compose.yml.
+func DefaultConfig() Config {
+	return Config{
+		Host:        "mailpit",
+		Port:        1025,
+		TLS:         "none",
+		Auth:        "none",
+		From:        "mailpit@planner.com",
+		PoolSize:    4,
+		IdleTimeout: Duration{30 * time.Second},
+		Timeout:     Duration{15 * time.Second},
+	}
+}
+
+// LoadConfig reads the JSON file named by PLANNER_SMTP_CONFIG, if any, and
+// then the PLANNER_SMTP_* variables, which take precedence over the file.
+func LoadConfig() (Config, error) {
+	// start from the defaults so an explicit pool size of 0 can be told from
+	// one that was left out
+	cfg := DefaultConfig()
+
+	if path := os.Getenv("PLANNER_SMTP_CONFIG"); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("smtp: failed to read config file: %w", err)
+		}
+		if err := json.Unmarshal(b, &cfg); err != nil {
+			return Config{}, fmt.Errorf("smtp: failed to parse config file: %w", err)
+		}
+	}
+
+	if err := cfg.readEnv(); err != nil {
+		return Config{}, err
+	}
+
+	cfg.setDefaults()
+	return cfg, cfg.validate()
+}
+
+func (cfg *Config) readEnv() error {
+	strs := map[string]*string{
+		"PLANNER_SMTP_HOST":     &cfg.Host,
+		"PLANNER_SMTP_TLS":      &cfg.TLS,
+		"PLANNER_SMTP_AUTH":     &cfg.Auth,
+		"PLANNER_SMTP_USERNAME": &cfg.Username,
+		"PLANNER_SMTP_PASSWORD": &cfg.Password,
+		"PLANNER_SMTP_FROM":     &cfg.From,
+	}
+	for key, field := range strs {
+		if v := os.Getenv(key); v != "" {
+			*field = v
+		}
+	}
+
+	ints := map[string]*int{
+		"PLANNER_SMTP_PORT":      &cfg.Port,
+		"PLANNER_SMTP_POOL_SIZE": &cfg.PoolSize,
+	}
+	for key, field := range ints {
+		if v := os.Getenv(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("smtp: invalid %s: %w", key, err)
+			}
+			*field = n
+		}
+	}
+
+	durations := map[string]*Duration{
+		"PLANNER_SMTP_IDLE_TIMEOUT": &cfg.IdleTimeout,
+		"PLANNER_SMTP_TIMEOUT":      &cfg.Timeout,
+	}
+	for key, field := range durations {
+		if v := os.Getenv(key); v != "" {
+			if err := field.UnmarshalText([]byte(v)); err != nil {
+				return fmt.Errorf("smtp: invalid %s: %w", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cfg *Config) setDefaults() {
+	def := DefaultConfig()
+	if cfg.Host == "" {
+		cfg.Host = def.Host
+	}
+	if cfg.Port == 0 {
+		cfg.Port = def.Port
+	}
+	if cfg.TLS == "" {
+		cfg.TLS = def.TLS
+	}
+	if cfg.Auth == "" {
+		cfg.Auth = def.Auth
+	}
+	if cfg.From == "" {
+		cfg.From = def.From
+	}
+	if cfg.IdleTimeout.Duration == 0 {
+		cfg.IdleTimeout = def.IdleTimeout
+	}
+	if cfg.Timeout.Duration == 0 {
+		cfg.Timeout = def.Timeout
+	}
+}
+
+func (cfg Config) validate() error {
+	if _, ok := tlsPolicies[cfg.TLS]; !ok && cfg.TLS != "implicit" {
+		return fmt.Errorf("smtp: unknown TLS mode %q", cfg.TLS)
+	}
+	if _, ok := authTypes[cfg.Auth]; !ok {
+		return fmt.Errorf("smtp: unknown auth mechanism %q", cfg.Auth)
+	}
+	if cfg.Auth != "none" && cfg.Username == "" {
+		return fmt.Errorf("smtp: auth mechanism %q needs a username", cfg.Auth)
+	}
+	if cfg.PoolSize < 0 {
+		return fmt.Errorf("smtp: pool size cannot be negative")
+	}
+	return nil
+}
+
+var tlsPolicies = map[string]mail.TLSPolicy{
+	"none":          mail.NoTLS,
+	"opportunistic": mail.TLSOpportunistic,
+	"mandatory":     mail.TLSMandatory,
+}
+
+var authTypes = map[string]mail.SMTPAuthType{
+	"none":     mail.SMTPAuthNoAuth,
+	"plain":    mail.SMTPAuthPlain,
+	"login":    mail.SMTPAuthLogin,
+	"cram-md5": mail.SMTPAuthCramMD5,
+}
+
+// options turns cfg into the options of a go-mail client.
+func (cfg Config) options() []mail.Option {
+	opts := []mail.Option{
+		mail.WithPort(cfg.Port),
+		mail.WithTimeout(cfg.Timeout.Duration),
+	}
+
+	if cfg.TLS == "implicit" {
+		opts = append(opts, mail.WithSSL())
+	} else {
+		opts = append(opts, mail.WithTLSPolicy(tlsPolicies[cfg.TLS]))
+	}
+
+	if cfg.Auth != "none" {
+		opts = append(opts,
+			mail.WithSMTPAuth(authTypes[cfg.Auth]),
+			mail.WithUsername(cfg.Username),
+			mail.WithPassword(cfg.Password),
+		)
+	}
+
+	return opts
+}
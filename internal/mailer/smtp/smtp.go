@@ -0,0 +1,120 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wneessen/go-mail"
+)
+
+type conn struct {
+	client   *mail.Client
+	lastUsed time.Time
+}
+
+// Transport sends messages to an SMTP server, keeping a small pool of open
+// connections so consecutive messages do not pay for a new handshake each.
+type Transport struct {
+	cfg  Config
+	idle chan conn
+}
+
+func NewTransport(cfg Config) (*Transport, error) {
+	// fail early on options go-mail would reject on every dial
+	if _, err := mail.NewClient(cfg.Host, cfg.options()...); err != nil {
+		return nil, fmt.Errorf("smtp: invalid config: %w", err)
+	}
+
+	return &Transport{cfg, make(chan conn, cfg.PoolSize)}, nil
+}
+
+// From is the address messages are sent from.
+func (t *Transport) From() string {
+	return t.cfg.From
+}
+
+// Send delivers msgs over a single connection. A pooled connection that turns
+// out to be closed by the server is replaced by a new one once.
+func (t *Transport) Send(ctx context.Context, msgs ...*mail.Msg) error {
+	c, pooled, err := t.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.client.Send(msgs...)
+	if err != nil && pooled && isConnCheck(err) {
+		c.client.Close()
+		if c, err = t.dial(ctx); err != nil {
+			return err
+		}
+		err = c.client.Send(msgs...)
+	}
+
+	if err != nil {
+		c.client.Close()
+		return fmt.Errorf("smtp: failed to send email: %w", err)
+	}
+
+	t.put(c)
+	return nil
+}
+
+// Close closes the idle connections.
+func (t *Transport) Close() {
+	for {
+		select {
+		case c := <-t.idle:
+			c.client.Close()
+		default:
+			return
+		}
+	}
+}
+
+// get returns an idle connection if there is one still fresh, or dials a new
+// one. pooled tells which of both it is.
+func (t *Transport) get(ctx context.Context) (conn, bool, error) {
+	for {
+		select {
+		case c := <-t.idle:
+			if time.Since(c.lastUsed) < t.cfg.IdleTimeout.Duration {
+				return c, true, nil
+			}
+			c.client.Close()
+		default:
+			c, err := t.dial(ctx)
+			return c, false, err
+		}
+	}
+}
+
+func (t *Transport) put(c conn) {
+	c.lastUsed = time.Now()
+	select {
+	case t.idle <- c:
+	default:
+		c.client.Close()
+	}
+}
+
+func (t *Transport) dial(ctx context.Context) (conn, error) {
+	client, err := mail.NewClient(t.cfg.Host, t.cfg.options()...)
+	if err != nil {
+		return conn{}, fmt.Errorf("smtp: failed to set client: %w", err)
+	}
+
+	if err := client.DialWithContext(ctx); err != nil {
+		return conn{}, fmt.Errorf("smtp: failed to dial %s:%d: %w", t.cfg.Host, t.cfg.Port, err)
+	}
+
+	return conn{client: client}, nil
+}
+
+// isConnCheck tells whether err comes from the connection being unusable
+// rather than from the messages.
+func isConnCheck(err error) bool {
+	var sendErr *mail.SendError
+	return errors.As(err, &sendErr) && sendErr.Reason == mail.ErrConnCheck
+}
@@ -0,0 +1,633 @@
+package smtpmailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"planner-go/internal/i18n"
+	"planner-go/internal/ical"
+	"planner-go/internal/magiclink"
+	"planner-go/internal/mailer/templates"
+	"planner-go/internal/outbox"
+	"planner-go/internal/pgstore"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wneessen/go-mail"
+)
+
+type store interface {
+	GetTrip(context.Context, uuid.UUID) (pgstore.Trip, error)
+	GetParticipants(context.Context, uuid.UUID) ([]pgstore.Participant, error)
+	GetParticipant(context.Context, uuid.UUID) (pgstore.Participant, error)
+	GetTripActivities(context.Context, uuid.UUID) ([]pgstore.Activity, error)
+	GetTripLinks(context.Context, uuid.UUID) ([]pgstore.Link, error)
+	ListDigestOptOuts(context.Context, uuid.UUID) ([]uuid.UUID, error)
+	GetUser(context.Context, uuid.UUID) (pgstore.User, error)
+	GetUserByEmail(context.Context, string) (pgstore.User, error)
+}
+
+// transport delivers the messages built by the mailer.
+type transport interface {
+	From() string
+	Send(ctx context.Context, msgs ...*mail.Msg) error
+}
+
+// Mailer sends the e-mails described by the outbox through an SMTP transport.
+type Mailer struct {
+	store     store
+	signer    magiclink.Signer
+	baseURL   string
+	transport transport
+	templates *templates.Set
+}
+
+func NewMailer(pool *pgxpool.Pool, signer magiclink.Signer, baseURL string, transport transport, templates *templates.Set) Mailer {
+	return Mailer{pgstore.New(pool), signer, baseURL, transport, templates}
+}
+
+// Deliver sends the e-mail described by a message of the outbox.
+func (mr Mailer) Deliver(ctx context.Context, msg pgstore.Outbox) error {
+	switch msg.Kind {
+	case pgstore.OutboxTripCreated:
+		var m pgstore.TripCreatedMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendConfirmEmailToTripOwner(ctx, m.TripID, m.Token)
+	case pgstore.OutboxParticipantInvited:
+		var m pgstore.ParticipantInvitedMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendConfirmEmailToInvitedParticipant(ctx, m.TripID, m.ParticipantID, m.Token, msg.CreatedAt.Time)
+	case pgstore.OutboxTripDeleted:
+		var m pgstore.TripDeletedMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendTripDeletedEmail(ctx, m.Trip, m.Participants)
+	case pgstore.OutboxActivityDeleted:
+		var m pgstore.ActivityDeletedMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendActivityDeletedEmail(ctx, m.Activity)
+	case pgstore.OutboxLinkDeleted:
+		var m pgstore.LinkDeletedMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendLinkDeletedEmail(ctx, m.Link)
+	case pgstore.OutboxParticipantRemoved:
+		var m pgstore.ParticipantRemovedMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendParticipantRemovedEmail(ctx, m.Trip, m.Participant)
+	case pgstore.OutboxInvitationReminder:
+		var m pgstore.ParticipantInvitedMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendInvitationReminder(ctx, m.TripID, m.ParticipantID, m.Token, msg.CreatedAt.Time)
+	case pgstore.OutboxPendingInvitations:
+		var m pgstore.TripMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendPendingInvitationsToOwner(ctx, m.TripID)
+	case pgstore.OutboxDailyDigest:
+		var m pgstore.DailyDigestMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		day, err := time.Parse(time.DateOnly, m.Day)
+		if err != nil {
+			return fmt.Errorf("%w: invalid day %q in %s payload", outbox.ErrUndeliverable, m.Day, msg.Kind)
+		}
+		return mr.SendDailyDigest(ctx, m.TripID, day)
+	case pgstore.OutboxTripCountdown:
+		var m pgstore.TripCountdownMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendTripCountdown(ctx, m.TripID, m.Days)
+	case pgstore.OutboxAccountClaim:
+		var m pgstore.AccountClaimMessage
+		if err := decode(msg, &m); err != nil {
+			return err
+		}
+		return mr.SendAccountClaimEmail(ctx, m)
+	}
+
+	return fmt.Errorf("%w: unknown kind %q", outbox.ErrUndeliverable, msg.Kind)
+}
+
+func decode(msg pgstore.Outbox, v any) error {
+	if err := json.Unmarshal(msg.Payload, v); err != nil {
+		return fmt.Errorf("%w: failed to decode %s payload: %v", outbox.ErrUndeliverable, msg.Kind, err)
+	}
+	return nil
+}
+
+// tokenLink returns the URL that consumes a confirmation token.
+func (mr Mailer) tokenLink(purpose, path string, token pgstore.Token) string {
+	return fmt.Sprintf("%s/confirmations/%s/%s", mr.baseURL, path, mr.signer.Sign(purpose, token.ID, token.ExpiresAt))
+}
+
+func (mr Mailer) SendConfirmEmailToTripOwner(ctx context.Context, tripId uuid.UUID, token pgstore.Token) error {
+	trip, err := mr.store.GetTrip(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip for SendConfirmEmailToTripOwner: %w", err)
+	}
+
+	activities, err := mr.activities(ctx, trip)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip activities for SendConfirmEmailToTripOwner: %w", err)
+	}
+
+	owner, err := mr.owner(ctx, trip)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip owner for SendConfirmEmailToTripOwner: %w", err)
+	}
+
+	return mr.send(ctx, "SendConfirmEmailToTripOwner", templates.TripConfirmation, templates.ConfirmationData{
+		Trip:       tripData(trip),
+		ConfirmURL: mr.tokenLink(magiclink.PurposeTrip, "trips", token),
+		Activities: activities,
+	}, owner)
+}
+
+func (mr Mailer) SendConfirmEmailToInvitedParticipant(ctx context.Context, tripId, participantId uuid.UUID, token pgstore.Token, queuedAt time.Time) error {
+	participant, err := mr.store.GetParticipant(ctx, participantId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip participants for SendConfirmEmailToInvitedParticipant: %w", err)
+	}
+
+	return mr.sendInvitation(ctx, "SendConfirmEmailToInvitedParticipant", templates.TripInvitation, tripId, participant, token, queuedAt)
+}
+
+func (mr Mailer) SendInvitationReminder(ctx context.Context, tripId, participantId uuid.UUID, token pgstore.Token, queuedAt time.Time) error {
+	participant, err := mr.store.GetParticipant(ctx, participantId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip participants for SendInvitationReminder: %w", err)
+	}
+
+	// the participant may have answered since the reminder was queued
+	if participant.Status != pgstore.RSVPInvited {
+		return nil
+	}
+
+	return mr.sendInvitation(ctx, "SendInvitationReminder", templates.InvitationReminder, tripId, participant, token, queuedAt)
+}
+
+// sendInvitation sends the e-mail name, which carries a confirmation link and
+// the invitation to the calendar of the participant. caller is only used to
+// give context to errors, and queuedAt stamps the invitation.
+func (mr Mailer) sendInvitation(ctx context.Context, caller, name string, tripId uuid.UUID, participant pgstore.Participant, token pgstore.Token, queuedAt time.Time) error {
+	trip, err := mr.store.GetTrip(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip for %s: %w", caller, err)
+	}
+
+	activities, err := mr.activities(ctx, trip)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip activities for %s: %w", caller, err)
+	}
+
+	msg, err := mr.message(caller, name, templates.ConfirmationData{
+		Trip:       tripData(trip),
+		ConfirmURL: mr.tokenLink(magiclink.PurposeParticipant, "participants", token),
+		Activities: activities,
+	}, participantRecipient(participant))
+	if err != nil {
+		return err
+	}
+
+	if err := mr.attachInvitation(msg, trip, participant, queuedAt); err != nil {
+		return fmt.Errorf("smtpmailer: failed to attach invitation in %s: %w", caller, err)
+	}
+
+	if err := mr.transport.Send(ctx, msg); err != nil {
+		return fmt.Errorf("smtpmailer: failed to send email in %s: %w", caller, err)
+	}
+
+	return nil
+}
+
+func (mr Mailer) SendPendingInvitationsToOwner(ctx context.Context, tripId uuid.UUID) error {
+	trip, err := mr.store.GetTrip(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip for SendPendingInvitationsToOwner: %w", err)
+	}
+
+	participants, err := mr.store.GetParticipants(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip participants for SendPendingInvitationsToOwner: %w", err)
+	}
+
+	var pending []templates.Invitee
+	for _, participant := range participants {
+		if participant.Status == pgstore.RSVPInvited || participant.Status == pgstore.RSVPMaybe {
+			pending = append(pending, templates.Invitee{
+				Name:  participant.Name.String,
+				Email: participant.Email,
+				Maybe: participant.Status == pgstore.RSVPMaybe,
+			})
+		}
+	}
+
+	// everyone answered since the summary was queued
+	if len(pending) == 0 {
+		return nil
+	}
+
+	to, err := mr.owner(ctx, trip)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip owner for SendPendingInvitationsToOwner: %w", err)
+	}
+
+	return mr.send(ctx, "SendPendingInvitationsToOwner", templates.PendingInvitations, templates.PendingData{
+		Trip:    tripData(trip),
+		Pending: pending,
+	}, to)
+}
+
+// SendDailyDigest sends the activities planned for day, a date in the time
+// zone of the trip, to the participants who did not turn the digests off.
+// Nothing is sent when no activity takes place on day.
+func (mr Mailer) SendDailyDigest(ctx context.Context, tripId uuid.UUID, day time.Time) error {
+	trip, err := mr.store.GetTrip(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip for SendDailyDigest: %w", err)
+	}
+
+	all, err := mr.store.GetTripActivities(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip activities for SendDailyDigest: %w", err)
+	}
+
+	var activities []templates.Activity
+	for _, activity := range all {
+		if activityOn(trip, activity, day) {
+			activities = append(activities, activityData(trip, activity))
+		}
+	}
+
+	if len(activities) == 0 {
+		return nil
+	}
+
+	to, err := mr.digestRecipients(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip participants for SendDailyDigest: %w", err)
+	}
+
+	links, err := mr.links(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip links for SendDailyDigest: %w", err)
+	}
+
+	return mr.send(ctx, "SendDailyDigest", templates.DailyDigest, templates.DigestData{
+		Trip:       tripData(trip),
+		Day:        day,
+		Activities: activities,
+		Links:      links,
+	}, to...)
+}
+
+func (mr Mailer) SendTripCountdown(ctx context.Context, tripId uuid.UUID, days int32) error {
+	trip, err := mr.store.GetTrip(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip for SendTripCountdown: %w", err)
+	}
+
+	to, err := mr.digestRecipients(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip participants for SendTripCountdown: %w", err)
+	}
+
+	activities, err := mr.activities(ctx, trip)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip activities for SendTripCountdown: %w", err)
+	}
+
+	links, err := mr.links(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip links for SendTripCountdown: %w", err)
+	}
+
+	return mr.send(ctx, "SendTripCountdown", templates.TripCountdown, templates.CountdownData{
+		Trip:       tripData(trip),
+		Days:       days,
+		Activities: activities,
+		Links:      links,
+	}, to...)
+}
+
+func (mr Mailer) SendAccountClaimEmail(ctx context.Context, claim pgstore.AccountClaimMessage) error {
+	user, err := mr.store.GetUser(ctx, claim.UserID)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get user for SendAccountClaimEmail: %w", err)
+	}
+
+	// the account was claimed through another link in the meantime
+	if user.PasswordHash != "" {
+		return nil
+	}
+
+	return mr.send(ctx, "SendAccountClaimEmail", templates.AccountClaim, templates.ClaimData{
+		Name:       user.Name,
+		ConfirmURL: mr.tokenLink(magiclink.PurposeAccount, "accounts", claim.Token),
+	}, recipient{claim.Email, i18n.Parse(claim.Locale)})
+}
+
+func (mr Mailer) SendTripDeletedEmail(ctx context.Context, trip pgstore.Trip, participants []pgstore.Participant) error {
+	var to []recipient
+	for _, participant := range participants {
+		if !participant.Removed() && !participant.Owns() {
+			to = append(to, participantRecipient(participant))
+		}
+	}
+
+	return mr.send(ctx, "SendTripDeletedEmail", templates.TripDeleted, templates.TripData{
+		Trip: tripData(trip),
+	}, to...)
+}
+
+func (mr Mailer) SendActivityDeletedEmail(ctx context.Context, activity pgstore.Activity) error {
+	trip, err := mr.store.GetTrip(ctx, activity.TripID)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip for SendActivityDeletedEmail: %w", err)
+	}
+
+	to, err := mr.confirmedParticipants(ctx, trip.ID)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip participants for SendActivityDeletedEmail: %w", err)
+	}
+
+	return mr.send(ctx, "SendActivityDeletedEmail", templates.ActivityDeleted, templates.ActivityData{
+		Trip:     tripData(trip),
+		Activity: activityData(trip, activity),
+	}, to...)
+}
+
+func (mr Mailer) SendLinkDeletedEmail(ctx context.Context, link pgstore.Link) error {
+	trip, err := mr.store.GetTrip(ctx, link.TripID)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip for SendLinkDeletedEmail: %w", err)
+	}
+
+	to, err := mr.confirmedParticipants(ctx, trip.ID)
+	if err != nil {
+		return fmt.Errorf("smtpmailer: failed to get trip participants for SendLinkDeletedEmail: %w", err)
+	}
+
+	return mr.send(ctx, "SendLinkDeletedEmail", templates.LinkDeleted, templates.LinkData{
+		Trip: tripData(trip),
+		Link: templates.Link{Title: link.Title, URL: link.Url},
+	}, to...)
+}
+
+func (mr Mailer) SendParticipantRemovedEmail(ctx context.Context, trip pgstore.Trip, participant pgstore.Participant) error {
+	return mr.send(ctx, "SendParticipantRemovedEmail", templates.ParticipantRemoved, templates.TripData{
+		Trip: tripData(trip),
+	}, participantRecipient(participant))
+}
+
+// invitationType is the content type of iTIP requests, which mail clients
+// show with buttons to accept or decline them.
+const invitationType = "text/calendar; charset=UTF-8; method=REQUEST"
+
+// attachInvitation adds to msg an iTIP request inviting participant to trip,
+// both as an alternative body, which is what most mail clients look for, and
+// as an attachment. The organizer is the sender address, so replies come back
+// to the mail server that hands them to the inbound endpoint of the API. The
+// request is stamped with queuedAt, when its outbox message was written, so a
+// message that is retried carries the same invitation each time.
+func (mr Mailer) attachInvitation(msg *mail.Msg, trip pgstore.Trip, participant pgstore.Participant, queuedAt time.Time) error {
+	organizer := mr.transport.From()
+	if addr, err := netmail.ParseAddress(organizer); err == nil {
+		organizer = addr.Address
+	}
+
+	partStat := ical.PartStatNeedsAction
+	switch participant.Status {
+	case pgstore.RSVPAccepted:
+		partStat = ical.PartStatAccepted
+	case pgstore.RSVPDeclined:
+		partStat = ical.PartStatDeclined
+	case pgstore.RSVPMaybe:
+		partStat = ical.PartStatTentative
+	}
+
+	locale := i18n.Parse(participant.Locale)
+	ics := ical.Calendar{
+		ProdID: ical.ProdID,
+		Method: ical.MethodRequest,
+		Events: []ical.Event{{
+			UID:       ical.UID("trip", trip.ID),
+			Stamp:     queuedAt,
+			AllDay:    true,
+			Start:     trip.StartsAt.Time.In(trip.Zone()),
+			End:       trip.EndsAt.Time.In(trip.Zone()),
+			Summary:   locale.Sprintf("Trip to %s", trip.Destination),
+			Location:  trip.Destination,
+			Organizer: &ical.Address{Name: trip.OwnerName, Email: organizer},
+			Attendees: []ical.Attendee{{
+				Address:  ical.Address{Email: participant.Email},
+				PartStat: partStat,
+				RSVP:     true,
+			}},
+		}},
+	}.Marshal()
+
+	msg.AddAlternativeString(invitationType, string(ics))
+	return msg.AttachReader("invite.ics", bytes.NewReader(ics), mail.WithFileContentType(invitationType))
+}
+
+// recipient is an address along with the locale its e-mails are written in.
+type recipient struct {
+	email  string
+	locale i18n.Locale
+}
+
+func participantRecipient(participant pgstore.Participant) recipient {
+	return recipient{participant.Email, i18n.Parse(participant.Locale)}
+}
+
+// owner returns the owner of trip as a recipient. Trips whose owner has no
+// account yet are written in i18n.Default.
+func (mr Mailer) owner(ctx context.Context, trip pgstore.Trip) (recipient, error) {
+	user, err := mr.store.GetUserByEmail(ctx, trip.OwnerEmail)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return recipient{trip.OwnerEmail, i18n.Default}, nil
+		}
+		return recipient{}, err
+	}
+	return recipient{trip.OwnerEmail, i18n.Parse(user.Locale)}, nil
+}
+
+// confirmedParticipants returns the participants of a trip who confirmed they
+// are taking part in it.
+func (mr Mailer) confirmedParticipants(ctx context.Context, tripId uuid.UUID) ([]recipient, error) {
+	participants, err := mr.store.GetParticipants(ctx, tripId)
+	if err != nil {
+		return nil, err
+	}
+
+	var to []recipient
+	for _, participant := range participants {
+		if participant.Accepted() {
+			to = append(to, participantRecipient(participant))
+		}
+	}
+	return to, nil
+}
+
+// digestRecipients returns the participants of a trip who confirmed they are
+// taking part in it and did not turn its digests off.
+func (mr Mailer) digestRecipients(ctx context.Context, tripId uuid.UUID) ([]recipient, error) {
+	participants, err := mr.store.GetParticipants(ctx, tripId)
+	if err != nil {
+		return nil, err
+	}
+
+	optOuts, err := mr.store.ListDigestOptOuts(ctx, tripId)
+	if err != nil {
+		return nil, err
+	}
+
+	optedOut := make(map[uuid.UUID]bool, len(optOuts))
+	for _, id := range optOuts {
+		optedOut[id] = true
+	}
+
+	var to []recipient
+	for _, participant := range participants {
+		if participant.Accepted() && !optedOut[participant.ID] {
+			to = append(to, participantRecipient(participant))
+		}
+	}
+	return to, nil
+}
+
+// activities returns the activities of a trip in the shape of the templates.
+func (mr Mailer) activities(ctx context.Context, trip pgstore.Trip) ([]templates.Activity, error) {
+	activities, err := mr.store.GetTripActivities(ctx, trip.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]templates.Activity, len(activities))
+	for i, activity := range activities {
+		data[i] = activityData(trip, activity)
+	}
+	return data, nil
+}
+
+// links returns the links of a trip in the shape of the templates.
+func (mr Mailer) links(ctx context.Context, tripId uuid.UUID) ([]templates.Link, error) {
+	links, err := mr.store.GetTripLinks(ctx, tripId)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]templates.Link, len(links))
+	for i, link := range links {
+		data[i] = templates.Link{Title: link.Title, URL: link.Url}
+	}
+	return data, nil
+}
+
+// activityOn tells whether activity takes place on day, from the day it starts
+// to the one it ends on. day is a date of the trip, so the days of the
+// activity are taken in the time zone of the trip as well, even if it has its
+// own: a flight that lands the next day where it lands is still listed on the
+// day of the trip it takes off on.
+func activityOn(trip pgstore.Trip, activity pgstore.Activity, day time.Time) bool {
+	loc := trip.Zone()
+	date := day.Format(time.DateOnly)
+
+	starts := activity.OccursAt.Time.In(loc).Format(time.DateOnly)
+	ends := starts
+	if activity.EndsAt.Valid {
+		ends = activity.EndsAt.Time.In(loc).Format(time.DateOnly)
+	}
+	return starts <= date && date <= ends
+}
+
+// tripData writes the dates of a trip in its time zone, and activityData the
+// time of an activity in its own.
+func tripData(trip pgstore.Trip) templates.Trip {
+	loc := trip.Zone()
+	return templates.Trip{
+		Destination: trip.Destination,
+		OwnerName:   trip.OwnerName,
+		StartsAt:    trip.StartsAt.Time.In(loc),
+		EndsAt:      trip.EndsAt.Time.In(loc),
+	}
+}
+
+func activityData(trip pgstore.Trip, activity pgstore.Activity) templates.Activity {
+	return templates.Activity{
+		Title:    activity.Title,
+		OccursAt: activity.OccursAt.Time.In(activity.Zone(trip)),
+		Location: activity.Location.String,
+	}
+}
+
+// message renders the e-mail name with data into a message to a single
+// recipient, in their locale. caller is only used to give context to errors.
+func (mr Mailer) message(caller, name string, data any, to recipient) (*mail.Msg, error) {
+	rendered, err := mr.templates.Render(name, to.locale, data)
+	if err != nil {
+		return nil, fmt.Errorf("smtpmailer: failed to render email in %s: %w", caller, err)
+	}
+
+	msg := mail.NewMsg()
+	if err := msg.From(mr.transport.From()); err != nil {
+		return nil, fmt.Errorf("smtpmailer: failed to set From in %s: %w", caller, err)
+	}
+
+	if err := msg.To(to.email); err != nil {
+		return nil, fmt.Errorf("smtpmailer: failed to set To in %s: %w", caller, err)
+	}
+
+	msg.Subject(rendered.Subject)
+	msg.SetGenHeader(mail.HeaderContentLang, string(to.locale))
+	msg.SetBodyString(mail.TypeTextPlain, rendered.Text)
+	msg.AddAlternativeString(mail.TypeTextHTML, rendered.HTML)
+	return msg, nil
+}
+
+// send delivers the same e-mail to each of the recipients, in their own
+// locale, over a single connection. caller is only used to give context to
+// errors.
+func (mr Mailer) send(ctx context.Context, caller, name string, data any, to ...recipient) error {
+	if len(to) == 0 {
+		return nil
+	}
+
+	msgs := make([]*mail.Msg, len(to))
+	for i, rcpt := range to {
+		msg, err := mr.message(caller, name, data, rcpt)
+		if err != nil {
+			return err
+		}
+		msgs[i] = msg
+	}
+
+	if err := mr.transport.Send(ctx, msgs...); err != nil {
+		return fmt.Errorf("smtpmailer: failed to send email in %s: %w", caller, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package smtpmailer
+
+import (
+	"planner-go/internal/pgstore"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestActivityOn(t *testing.T) {
+	trip := pgstore.Trip{TimeZone: "America/Sao_Paulo"}
+	at := func(s string) pgtype.Timestamptz {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pgtype.Timestamptz{Time: ts, Valid: true}
+	}
+	day, err := time.Parse(time.DateOnly, "2024-07-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		activity pgstore.Activity
+		want     bool
+	}{
+		{"on the day", pgstore.Activity{OccursAt: at("2024-07-02T12:00:00-03:00")}, true},
+		{"late on the day, next day in UTC", pgstore.Activity{OccursAt: at("2024-07-02T23:30:00-03:00")}, true},
+		{"day before", pgstore.Activity{OccursAt: at("2024-07-01T23:30:00-03:00")}, false},
+		{"runs across the day", pgstore.Activity{OccursAt: at("2024-07-01T20:00:00-03:00"), EndsAt: at("2024-07-03T08:00:00-03:00")}, true},
+		{"ends at the start of the day", pgstore.Activity{OccursAt: at("2024-07-01T20:00:00-03:00"), EndsAt: at("2024-07-02T00:00:00-03:00")}, true},
+		{
+			// 2024-07-03 in Tokyo, still 2024-07-02 for the trip
+			"own zone on the next day",
+			pgstore.Activity{OccursAt: at("2024-07-02T22:00:00-03:00"), TimeZone: pgtype.Text{String: "Asia/Tokyo", Valid: true}},
+			true,
+		},
+		{
+			// 2024-07-02 in Tokyo, still 2024-07-01 for the trip
+			"own zone on the day before",
+			pgstore.Activity{OccursAt: at("2024-07-01T20:00:00-03:00"), TimeZone: pgtype.Text{String: "Asia/Tokyo", Valid: true}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activityOn(trip, tt.activity, day); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"planner-go/internal/i18n"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Names of the e-mails. Each one is made of a <name>.txt file, which also
+// defines the subject, and a <name>.html file.
+const (
+	TripConfirmation   = "trip_confirmation"
+	TripInvitation     = "trip_invitation"
+	TripDeleted        = "trip_deleted"
+	ActivityDeleted    = "activity_deleted"
+	LinkDeleted        = "link_deleted"
+	ParticipantRemoved = "participant_removed"
+	InvitationReminder = "invitation_reminder"
+	PendingInvitations = "pending_invitations"
+	DailyDigest        = "daily_digest"
+	TripCountdown      = "trip_countdown"
+	AccountClaim       = "account_claim"
+)
+
+var names = []string{
+	TripConfirmation,
+	TripInvitation,
+	TripDeleted,
+	ActivityDeleted,
+	LinkDeleted,
+	ParticipantRemoved,
+	InvitationReminder,
+	PendingInvitations,
+	DailyDigest,
+	TripCountdown,
+	AccountClaim,
+}
+
+// partials are parsed along with every e-mail, layout first.
+var partials = []string{"layout", "activities", "links"}
+
+//go:embed mail
+var embedded embed.FS
+
+type Trip struct {
+	Destination string
+	OwnerName   string
+	StartsAt    time.Time
+	EndsAt      time.Time
+}
+
+type Activity struct {
+	Title    string
+	OccursAt time.Time
+	Location string
+}
+
+type Link struct {
+	Title string
+	URL   string
+}
+
+// ConfirmationData is the data of TripConfirmation, TripInvitation and
+// InvitationReminder.
+type ConfirmationData struct {
+	Trip       Trip
+	ConfirmURL string
+	Activities []Activity
+}
+
+// TripData is the data of TripDeleted and ParticipantRemoved.
+type TripData struct {
+	Trip Trip
+}
+
+// ActivityData is the data of ActivityDeleted.
+type ActivityData struct {
+	Trip     Trip
+	Activity Activity
+}
+
+// LinkData is the data of LinkDeleted.
+type LinkData struct {
+	Trip Trip
+	Link Link
+}
+
+// Invitee is a participant who has not accepted the invitation yet. Maybe
+// tells those who answered they might come from those who did not answer.
+type Invitee struct {
+	Name  string
+	Email string
+	Maybe bool
+}
+
+// PendingData is the data of PendingInvitations.
+type PendingData struct {
+	Trip    Trip
+	Pending []Invitee
+}
+
+// DigestData is the data of DailyDigest, which lists the activities of Day
+// along with the links of the trip.
+type DigestData struct {
+	Trip       Trip
+	Day        time.Time
+	Activities []Activity
+	Links      []Link
+}
+
+// CountdownData is the data of TripCountdown, sent Days before the trip starts.
+type CountdownData struct {
+	Trip       Trip
+	Days       int32
+	Activities []Activity
+	Links      []Link
+}
+
+// ClaimData is the data of AccountClaim. Name is the one the account was
+// signed up with.
+type ClaimData struct {
+	Name       string
+	ConfirmURL string
+}
+
+// Message is a rendered e-mail.
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Set holds the parsed e-mails of every supported locale.
+type Set struct {
+	text map[key]*texttemplate.Template
+	html map[key]*htmltemplate.Template
+}
+
+type key struct {
+	name   string
+	locale i18n.Locale
+}
+
+// textFuncs are the functions available to the .txt files. Text is translated
+// with t, which takes an English format and its arguments like fmt.Sprintf.
+func textFuncs(locale i18n.Locale) texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"t":        locale.Sprintf,
+		"date":     locale.Date,
+		"datetime": locale.DateTime,
+	}
+}
+
+// htmlFuncs are the functions available to the .html files. Their t escapes
+// the translated text and the arguments, except for those already made HTML
+// by strong, so markup can be placed inside a sentence.
+func htmlFuncs(locale i18n.Locale) htmltemplate.FuncMap {
+	return htmltemplate.FuncMap{
+		"t": func(format string, args ...any) htmltemplate.HTML {
+			for i, arg := range args {
+				if _, ok := arg.(htmltemplate.HTML); !ok {
+					args[i] = htmltemplate.HTMLEscapeString(fmt.Sprint(arg))
+				}
+			}
+			msg := htmltemplate.HTMLEscapeString(locale.Sprintf(format))
+			if len(args) > 0 {
+				msg = fmt.Sprintf(msg, args...)
+			}
+			return htmltemplate.HTML(msg)
+		},
+		"strong": func(v any) htmltemplate.HTML {
+			return htmltemplate.HTML("<strong>" + htmltemplate.HTMLEscapeString(fmt.Sprint(v)) + "</strong>")
+		},
+		"lang":     func() string { return string(locale) },
+		"date":     locale.Date,
+		"datetime": locale.DateTime,
+	}
+}
+
+// Load parses the embedded templates once for each supported locale. Files
+// found in override, when it is not nil, replace the embedded files of the
+// same name, so an installation can restyle some e-mails and keep the rest.
+func Load(override fs.FS) (*Set, error) {
+	set := &Set{
+		text: make(map[key]*texttemplate.Template, len(names)*len(i18n.Supported)),
+		html: make(map[key]*htmltemplate.Template, len(names)*len(i18n.Supported)),
+	}
+
+	for _, name := range names {
+		files := append(append([]string{}, partials...), name)
+		sources := make(map[string]string, len(files)*2)
+		for _, file := range files {
+			for _, ext := range []string{".txt", ".html"} {
+				src, err := read(override, file+ext)
+				if err != nil {
+					return nil, err
+				}
+				sources[file+ext] = src
+			}
+		}
+
+		for _, locale := range i18n.Supported {
+			text := texttemplate.New(name).Funcs(textFuncs(locale))
+			html := htmltemplate.New(name).Funcs(htmlFuncs(locale))
+			for _, file := range files {
+				var err error
+				if text, err = text.New(file + ".txt").Parse(sources[file+".txt"]); err != nil {
+					return nil, fmt.Errorf("templates: failed to parse %s.txt: %w", file, err)
+				}
+				if html, err = html.New(file + ".html").Parse(sources[file+".html"]); err != nil {
+					return nil, fmt.Errorf("templates: failed to parse %s.html: %w", file, err)
+				}
+			}
+
+			if text.Lookup("subject") == nil {
+				return nil, fmt.Errorf("templates: %s.txt does not define a subject", name)
+			}
+
+			set.text[key{name, locale}] = text
+			set.html[key{name, locale}] = html
+		}
+	}
+
+	return set, nil
+}
+
+func read(override fs.FS, file string) (string, error) {
+	if override != nil {
+		b, err := fs.ReadFile(override, file)
+		if err == nil {
+			return string(b), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("templates: failed to read %s: %w", file, err)
+		}
+	}
+
+	b, err := embedded.ReadFile("mail/" + file)
+	if err != nil {
+		return "", fmt.Errorf("templates: failed to read %s: %w", file, err)
+	}
+	return string(b), nil
+}
+
+// Render executes the e-mail name with data in locale. Locales without a
+// catalog fall back to i18n.Default.
+func (s *Set) Render(name string, locale i18n.Locale, data any) (Message, error) {
+	k := key{name, locale}
+	if _, ok := s.text[k]; !ok {
+		k.locale = i18n.Default
+	}
+
+	text, ok := s.text[k]
+	if !ok {
+		return Message{}, fmt.Errorf("templates: unknown template %q", name)
+	}
+
+	var subject, body, html bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("templates: failed to render subject of %s: %w", name, err)
+	}
+	if err := text.ExecuteTemplate(&body, "layout.txt", data); err != nil {
+		return Message{}, fmt.Errorf("templates: failed to render %s.txt: %w", name, err)
+	}
+	if err := s.html[k].ExecuteTemplate(&html, "layout.html", data); err != nil {
+		return Message{}, fmt.Errorf("templates: failed to render %s.html: %w", name, err)
+	}
+
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
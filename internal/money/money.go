@@ -0,0 +1,130 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/text/currency"
+)
+
+var (
+	// ErrInvalidAmount is returned for amounts that are not plain decimal
+	// numbers, such as "12.50".
+	ErrInvalidAmount = errors.New("money: invalid amount")
+	// ErrTooPrecise is returned for amounts with more decimal places than the
+	// currency has.
+	ErrTooPrecise = errors.New("money: amount has more decimal places than the currency")
+)
+
+// Scale returns the number of decimal places of an ISO 4217 currency, such as
+// 2 for USD and 0 for JPY. Unknown codes are taken to have 2.
+func Scale(code string) int {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return 2
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	return scale
+}
+
+// Parse turns a non-negative decimal string into minor units of the currency,
+// so "12.5" is 1250 in USD. Amounts are never read through a float.
+func Parse(s string, code string) (int64, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || !digits(whole) || !digits(frac) {
+		return 0, ErrInvalidAmount
+	}
+
+	scale := Scale(code)
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > scale {
+		return 0, ErrTooPrecise
+	}
+
+	n, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", scale-len(frac)), 10)
+	if !ok || !n.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return n.Int64(), nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Format writes minor units of the currency as a decimal string with all of
+// its decimal places, so 1250 in USD is "12.50".
+func Format(minor int64, code string) string {
+	return Rat(minor, code).FloatString(Scale(code))
+}
+
+// Rat returns minor units of the currency as an exact number of whole units.
+func Rat(minor int64, code string) *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(minor), pow10(Scale(code)))
+}
+
+// FromRat rounds an amount of whole units to minor units of the currency,
+// half away from zero.
+func FromRat(r *big.Rat, code string) int64 {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(Scale(code))))
+
+	quo, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	// the remainder is at least half of the denominator when twice it is
+	twice := new(big.Int).Abs(rem)
+	if twice.Lsh(twice, 1).Cmp(scaled.Denom()) >= 0 {
+		if rem.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo.Int64()
+}
+
+// Numeric returns minor units of the currency as a numeric column, which
+// keeps the exact decimal amount in the currency.
+func Numeric(minor int64, code string) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(minor), Exp: -int32(Scale(code)), Valid: true}
+}
+
+// FromNumeric returns a numeric column in minor units of the currency. It
+// fails for amounts finer than the currency, which are never stored.
+func FromNumeric(n pgtype.Numeric, code string) (int64, error) {
+	r, err := NumericRat(n)
+	if err != nil {
+		return 0, err
+	}
+
+	scaled := r.Mul(r, new(big.Rat).SetInt(pow10(Scale(code))))
+	if !scaled.IsInt() || !scaled.Num().IsInt64() {
+		return 0, fmt.Errorf("money: %s is not a whole amount of %s minor units", r.FloatString(8), code)
+	}
+	return scaled.Num().Int64(), nil
+}
+
+// NumericRat returns a numeric column as an exact rational number.
+func NumericRat(n pgtype.Numeric) (*big.Rat, error) {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite {
+		return nil, ErrInvalidAmount
+	}
+
+	r := new(big.Rat).SetInt(n.Int)
+	if n.Exp > 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow10(int(n.Exp))))
+	} else if n.Exp < 0 {
+		r.Quo(r, new(big.Rat).SetInt(pow10(int(-n.Exp))))
+	}
+	return r, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
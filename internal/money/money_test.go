@@ -0,0 +1,159 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		code string
+		want int
+	}{
+		{"USD", 2},
+		{"EUR", 2},
+		{"JPY", 0},
+		{"KWD", 3},
+		{"not a code", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := Scale(tt.code); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		code    string
+		want    int64
+		wantErr error
+	}{
+		{"whole", "12", "USD", 1200, nil},
+		{"one decimal", "12.5", "USD", 1250, nil},
+		{"two decimals", "12.50", "USD", 1250, nil},
+		{"trailing zeros", "12.5000", "USD", 1250, nil},
+		{"trailing dot", "12.", "USD", 1200, nil},
+		{"leading zeros", "007.05", "USD", 705, nil},
+		{"zero", "0", "USD", 0, nil},
+		{"no decimals in currency", "1500", "JPY", 1500, nil},
+		{"three decimals in currency", "1.234", "KWD", 1234, nil},
+		{"too precise", "12.505", "USD", 0, ErrTooPrecise},
+		{"too precise for currency", "1500.5", "JPY", 0, ErrTooPrecise},
+		{"empty", "", "USD", 0, ErrInvalidAmount},
+		{"missing whole part", ".50", "USD", 0, ErrInvalidAmount},
+		{"negative", "-12.50", "USD", 0, ErrInvalidAmount},
+		{"comma", "12,50", "USD", 0, ErrInvalidAmount},
+		{"exponent", "1e3", "USD", 0, ErrInvalidAmount},
+		{"two dots", "1.2.3", "USD", 0, ErrInvalidAmount},
+		{"overflow", "999999999999999999999", "USD", 0, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.s, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		minor int64
+		code  string
+		want  string
+	}{
+		{1250, "USD", "12.50"},
+		{5, "USD", "0.05"},
+		{0, "USD", "0.00"},
+		{-1250, "USD", "-12.50"},
+		{1500, "JPY", "1500"},
+		{1234, "KWD", "1.234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Format(tt.minor, tt.code); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			back, err := Parse(tt.want, tt.code)
+			if tt.minor >= 0 && (err != nil || back != tt.minor) {
+				t.Errorf("Parse(%q) = %d, %v, want %d", tt.want, back, err, tt.minor)
+			}
+		})
+	}
+}
+
+func TestFromRat(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *big.Rat
+		code string
+		want int64
+	}{
+		{"exact", big.NewRat(1250, 100), "USD", 1250},
+		{"down", big.NewRat(12344, 1000), "USD", 1234},
+		{"half up", big.NewRat(12345, 1000), "USD", 1235},
+		{"up", big.NewRat(12346, 1000), "USD", 1235},
+		{"third", big.NewRat(1, 3), "USD", 33},
+		{"two thirds", big.NewRat(2, 3), "USD", 67},
+		{"negative half away from zero", big.NewRat(-12345, 1000), "USD", -1235},
+		{"negative down", big.NewRat(-12344, 1000), "USD", -1234},
+		{"no decimals", big.NewRat(3, 2), "JPY", 2},
+		{"no decimals down", big.NewRat(7, 5), "JPY", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromRat(tt.r, tt.code); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       pgtype.Numeric
+		code    string
+		want    int64
+		wantErr bool
+	}{
+		{"same scale", Numeric(1250, "USD"), "USD", 1250, false},
+		{"fewer decimals", pgtype.Numeric{Int: big.NewInt(125), Exp: -1, Valid: true}, "USD", 1250, false},
+		{"more zeros", pgtype.Numeric{Int: big.NewInt(125000), Exp: -4, Valid: true}, "USD", 1250, false},
+		{"positive exponent", pgtype.Numeric{Int: big.NewInt(15), Exp: 2, Valid: true}, "JPY", 1500, false},
+		{"three decimals", Numeric(1234, "KWD"), "KWD", 1234, false},
+		{"finer than the currency", pgtype.Numeric{Int: big.NewInt(12345), Exp: -3, Valid: true}, "USD", 0, true},
+		{"null", pgtype.Numeric{}, "USD", 0, true},
+		{"not a number", pgtype.Numeric{NaN: true, Valid: true}, "USD", 0, true},
+		{"infinity", pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, "USD", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromNumeric(tt.n, tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
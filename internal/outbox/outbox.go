@@ -0,0 +1,191 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"planner-go/internal/pgstore"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// ErrUndeliverable marks a delivery error that retrying will not fix, such as
+// a payload that cannot be decoded. Messages failing with it are dead at once.
+var ErrUndeliverable = errors.New("outbox: message cannot be delivered")
+
+// Handler delivers the messages taken from the outbox.
+type Handler interface {
+	Deliver(ctx context.Context, msg pgstore.Outbox) error
+}
+
+type Config struct {
+	// PollInterval is how long the dispatcher sleeps when the outbox is empty.
+	PollInterval time.Duration
+	// BatchSize is how many messages are claimed at once.
+	BatchSize int32
+	// MaxAttempts is how many times a message is tried before it is dead.
+	MaxAttempts int32
+	// MinBackoff is the wait after the first failure. It doubles on each
+	// failure after that, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// DeliveryTimeout bounds the delivery of a single message.
+	DeliveryTimeout time.Duration
+	// Lease is how long claimed messages are kept from other instances while
+	// they are delivered. It must cover the delivery of a whole batch, or
+	// another instance may send a message again before its outcome is stored.
+	Lease time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:    2 * time.Second,
+		BatchSize:       10,
+		MaxAttempts:     8,
+		MinBackoff:      30 * time.Second,
+		MaxBackoff:      time.Hour,
+		DeliveryTimeout: 30 * time.Second,
+		Lease:           10 * time.Minute,
+	}
+}
+
+// Dispatcher delivers the messages written to the outbox. Delivery is at least
+// once: a message whose outcome could not be stored is delivered again once
+// its lease runs out.
+type Dispatcher struct {
+	store   *pgstore.Queries
+	handler Handler
+	logger  *zap.Logger
+	cfg     Config
+}
+
+func NewDispatcher(pool *pgxpool.Pool, handler Handler, logger *zap.Logger, cfg Config) *Dispatcher {
+	return &Dispatcher{pgstore.New(pool), handler, logger, cfg}
+}
+
+// Run delivers messages until ctx is done. A batch that is being delivered
+// when ctx is done is finished first.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		n, err := d.dispatch(context.WithoutCancel(ctx))
+		if err != nil {
+			d.logger.Error("Failed to dispatch outbox messages", zap.Error(err))
+		}
+
+		// a full batch means there may be more waiting
+		if err == nil && n == int(d.cfg.BatchSize) {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.cfg.PollInterval):
+		}
+	}
+}
+
+// Drain delivers the messages that are due until there are none left or ctx
+// is done. It is meant for shutdown, after Run has returned.
+func (d *Dispatcher) Drain(ctx context.Context) error {
+	for {
+		n, err := d.dispatch(ctx)
+		if err != nil {
+			return err
+		}
+		if n < int(d.cfg.BatchSize) {
+			return nil
+		}
+	}
+}
+
+// dispatch claims a batch of due messages and delivers them. Claiming leases
+// the messages by pushing their next attempt past the lease, so other
+// instances skip them without a transaction being held open while the mail
+// server is talked to. The outcome of each message is stored on its own.
+func (d *Dispatcher) dispatch(ctx context.Context) (int, error) {
+	msgs, err := d.store.ClaimOutboxMessages(ctx, pgstore.ClaimOutboxMessagesParams{
+		LeasedUntil: pgtype.Timestamptz{Time: time.Now().Add(d.cfg.Lease), Valid: true},
+		BatchSize:   d.cfg.BatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("outbox: failed to claim messages for dispatch: %w", err)
+	}
+
+	var errs []error
+	for _, msg := range msgs {
+		if err := d.deliver(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(msgs), errors.Join(errs...)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, msg pgstore.Outbox) error {
+	deliverCtx, cancel := context.WithTimeout(ctx, d.cfg.DeliveryTimeout)
+	err := d.handler.Deliver(deliverCtx, msg)
+	cancel()
+
+	if err == nil {
+		if err := d.store.MarkOutboxMessageSent(ctx, msg.ID); err != nil {
+			return fmt.Errorf("outbox: failed to mark message as sent: %w", err)
+		}
+		return nil
+	}
+
+	attempts := msg.Attempts + 1
+	lastError := pgtype.Text{String: err.Error(), Valid: true}
+
+	if errors.Is(err, ErrUndeliverable) || attempts >= d.cfg.MaxAttempts {
+		d.logger.Error("Outbox message is dead",
+			zap.Error(err),
+			zap.String("message_id", msg.ID.String()),
+			zap.String("kind", msg.Kind),
+			zap.Int32("attempts", attempts))
+
+		if err := d.store.MarkOutboxMessageDead(ctx, pgstore.MarkOutboxMessageDeadParams{
+			ID:        msg.ID,
+			LastError: lastError,
+		}); err != nil {
+			return fmt.Errorf("outbox: failed to mark message as dead: %w", err)
+		}
+		return nil
+	}
+
+	retryAt := time.Now().Add(d.backoff(attempts))
+	d.logger.Warn("Failed to deliver outbox message",
+		zap.Error(err),
+		zap.String("message_id", msg.ID.String()),
+		zap.String("kind", msg.Kind),
+		zap.Int32("attempts", attempts),
+		zap.Time("retry_at", retryAt))
+
+	if err := d.store.RetryOutboxMessage(ctx, pgstore.RetryOutboxMessageParams{
+		ID:            msg.ID,
+		LastError:     lastError,
+		NextAttemptAt: pgtype.Timestamptz{Time: retryAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("outbox: failed to reschedule message: %w", err)
+	}
+	return nil
+}
+
+// backoff is the wait before the next try of a message that failed attempts
+// times.
+func (d *Dispatcher) backoff(attempts int32) time.Duration {
+	wait := d.cfg.MinBackoff
+	for i := int32(1); i < attempts; i++ {
+		wait *= 2
+		if wait >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return wait
+}
@@ -9,6 +9,75 @@ import (
 	"context"
 )
 
+// iteratorForInsertBudgetCategories implements pgx.CopyFromSource.
+type iteratorForInsertBudgetCategories struct {
+	rows                 []InsertBudgetCategoriesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertBudgetCategories) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertBudgetCategories) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].TripID,
+		r.rows[0].Category,
+		r.rows[0].Amount,
+	}, nil
+}
+
+func (r iteratorForInsertBudgetCategories) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertBudgetCategories(ctx context.Context, arg []InsertBudgetCategoriesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"budget_categories"}, []string{"trip_id", "category", "amount"}, &iteratorForInsertBudgetCategories{rows: arg})
+}
+
+// iteratorForInsertExpenseShares implements pgx.CopyFromSource.
+type iteratorForInsertExpenseShares struct {
+	rows                 []InsertExpenseSharesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertExpenseShares) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertExpenseShares) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ExpenseID,
+		r.rows[0].ParticipantID,
+		r.rows[0].Shares,
+		r.rows[0].Amount,
+	}, nil
+}
+
+func (r iteratorForInsertExpenseShares) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertExpenseShares(ctx context.Context, arg []InsertExpenseSharesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"expense_shares"}, []string{"expense_id", "participant_id", "shares", "amount"}, &iteratorForInsertExpenseShares{rows: arg})
+}
+
 // iteratorForInviteParticipantsToTrip implements pgx.CopyFromSource.
 type iteratorForInviteParticipantsToTrip struct {
 	rows                 []InviteParticipantsToTripParams
@@ -31,6 +100,8 @@ func (r iteratorForInviteParticipantsToTrip) Values() ([]interface{}, error) {
 	return []interface{}{
 		r.rows[0].TripID,
 		r.rows[0].Email,
+		r.rows[0].Locale,
+		r.rows[0].Name,
 	}, nil
 }
 
@@ -39,5 +110,5 @@ func (r iteratorForInviteParticipantsToTrip) Err() error {
 }
 
 func (q *Queries) InviteParticipantsToTrip(ctx context.Context, arg []InviteParticipantsToTripParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"participants"}, []string{"trip_id", "email"}, &iteratorForInviteParticipantsToTrip{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"participants"}, []string{"trip_id", "email", "locale", "name"}, &iteratorForInviteParticipantsToTrip{rows: arg})
 }
@@ -0,0 +1,28 @@
+package pgstore
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrParticipantRemoved is returned when a participant removed from a trip
+// tries to answer its invitation.
+var ErrParticipantRemoved = errors.New("pgstore: participant was removed from the trip")
+
+// ErrInvitationExpired is returned when a participant answers an invitation
+// that expired.
+var ErrInvitationExpired = errors.New("pgstore: invitation has expired")
+
+// ErrParticipantExists is returned when an address is invited to a trip it
+// already takes part in, whatever its case.
+var ErrParticipantExists = errors.New("pgstore: participant already takes part in the trip")
+
+// ErrAccountClaimed is returned when the link to claim a placeholder account is
+// opened after the account was claimed by another one.
+var ErrAccountClaimed = errors.New("pgstore: account was already claimed")
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,79 @@
+package pgstore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"planner-go/internal/exchange"
+	"planner-go/internal/money"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Rate implements exchange.Provider with the exchange_rates table. Only the
+// latest rate of each pair involving either currency is loaded, which is all
+// exchange.Table needs to convert between them directly or through a third
+// one.
+func (q *Queries) Rate(ctx context.Context, from, to string, on time.Time) (*big.Rat, error) {
+	rows, err := q.GetExchangeRates(ctx, GetExchangeRatesParams{
+		Currencies: []string{from, to},
+		ValidOn:    pgtype.Date{Time: on, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to get exchange rates for Rate: %w", err)
+	}
+
+	rates := make([]exchange.Rate, len(rows))
+	for i, row := range rows {
+		value, err := money.NumericRat(row.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("pgstore: invalid exchange rate from %s to %s: %w", row.Base, row.Quote, err)
+		}
+		rates[i] = exchange.Rate{
+			Base:    row.Base,
+			Quote:   row.Quote,
+			ValidOn: row.ValidOn.Time,
+			Value:   value,
+		}
+	}
+
+	return exchange.NewTable(rates).Rate(ctx, from, to, on)
+}
+
+// ImportExchangeRates stores rates, such as those of a file, replacing the
+// ones of the same pair and day.
+func (q *Queries) ImportExchangeRates(ctx context.Context, pool *pgxpool.Pool, rates []exchange.Rate) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for ImportExchangeRates: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	for _, rate := range rates {
+		var value pgtype.Numeric
+		// the column keeps 12 decimal places, more than any published rate
+		if err := value.Scan(rate.Value.FloatString(12)); err != nil {
+			return fmt.Errorf("pgstore: invalid rate from %s to %s for ImportExchangeRates: %w", rate.Base, rate.Quote, err)
+		}
+
+		if err := qtx.UpsertExchangeRate(ctx, UpsertExchangeRateParams{
+			Base:    rate.Base,
+			Quote:   rate.Quote,
+			ValidOn: pgtype.Date{Time: rate.ValidOn, Valid: true},
+			Rate:    value,
+		}); err != nil {
+			return fmt.Errorf("pgstore: failed to upsert rate for ImportExchangeRates: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit trx for ImportExchangeRates: %w", err)
+	}
+
+	return nil
+}
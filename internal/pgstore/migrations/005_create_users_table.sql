@@ -0,0 +1,11 @@
+create table
+  IF not exists users (
+    "id" uuid primary KEY not null default gen_random_uuid(),
+    "email" varchar(255) not null unique,
+    "name" varchar(255) not null,
+    "password_hash" varchar(255) not null,
+    "created_at" timestamptz not null default now()
+  );
+
+---- create above / drop below ----
+drop table IF exists users;
\ No newline at end of file
@@ -0,0 +1,12 @@
+create table
+  IF not exists sessions (
+    "id" uuid primary KEY not null default gen_random_uuid(),
+    "user_id" uuid not null,
+    "token_hash" bytea not null unique,
+    "expires_at" timestamptz not null,
+    "created_at" timestamptz not null default now(),
+    foreign KEY (user_id) references users (id) on update CASCADE on delete CASCADE
+  );
+
+---- create above / drop below ----
+drop table IF exists sessions;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- trips created before accounts existed get a placeholder user with an empty
+-- password hash, which can be claimed later through a link sent to its email.
+-- Signing up lower-cases the email, so the owners are lower-cased as well.
+update trips
+set
+  "owner_email" = lower("owner_email");
+
+insert into
+  users ("email", "name", "password_hash")
+select distinct on ("owner_email") "owner_email", "owner_name", ''
+from trips
+on conflict ("email") do nothing;
+
+alter table trips
+  add constraint trips_owner_email_fkey foreign KEY (owner_email) references users (email) on update CASCADE;
+
+---- create above / drop below ----
+alter table trips drop constraint IF exists trips_owner_email_fkey;
@@ -0,0 +1,5 @@
+alter table participants
+  add column "role" varchar(16) not null default 'editor' check ("role" in ('owner', 'editor', 'viewer'));
+
+---- create above / drop below ----
+alter table participants drop column IF exists "role";
\ No newline at end of file
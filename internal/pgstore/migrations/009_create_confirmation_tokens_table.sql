@@ -0,0 +1,12 @@
+create table
+  IF not exists confirmation_tokens (
+    "id" uuid primary KEY not null default gen_random_uuid(),
+    "purpose" varchar(16) not null check ("purpose" in ('trip', 'participant')),
+    "subject_id" uuid not null,
+    "expires_at" timestamptz not null,
+    "used_at" timestamptz,
+    "created_at" timestamptz not null default now()
+  );
+
+---- create above / drop below ----
+drop table IF exists confirmation_tokens;
\ No newline at end of file
@@ -0,0 +1,16 @@
+create index IF not exists trips_lower_owner_email_idx on trips (lower(owner_email));
+
+create index IF not exists trips_starts_at_idx on trips (starts_at);
+
+create index IF not exists participants_trip_id_lower_email_idx on participants (trip_id, lower(email));
+
+create index IF not exists participants_lower_email_idx on participants (lower(email));
+
+---- create above / drop below ----
+drop index IF exists participants_lower_email_idx;
+
+drop index IF exists participants_trip_id_lower_email_idx;
+
+drop index IF exists trips_starts_at_idx;
+
+drop index IF exists trips_lower_owner_email_idx;
\ No newline at end of file
@@ -0,0 +1,22 @@
+alter table links
+  add column "created_at" timestamptz not null default now();
+
+alter table participants
+  add column "created_at" timestamptz not null default now();
+
+create index IF not exists activities_trip_id_occurs_at_id_idx on activities (trip_id, occurs_at, id);
+
+create index IF not exists links_trip_id_created_at_id_idx on links (trip_id, created_at, id);
+
+create index IF not exists participants_trip_id_created_at_id_idx on participants (trip_id, created_at, id);
+
+---- create above / drop below ----
+drop index IF exists participants_trip_id_created_at_id_idx;
+
+drop index IF exists links_trip_id_created_at_id_idx;
+
+drop index IF exists activities_trip_id_occurs_at_id_idx;
+
+alter table participants drop column IF exists "created_at";
+
+alter table links drop column IF exists "created_at";
\ No newline at end of file
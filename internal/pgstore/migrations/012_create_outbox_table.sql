@@ -0,0 +1,19 @@
+create table
+  IF not exists outbox (
+    "id" uuid primary KEY not null default gen_random_uuid(),
+    "kind" varchar(64) not null,
+    "payload" jsonb not null,
+    "status" varchar(16) not null default 'pending' check ("status" in ('pending', 'sent', 'dead')),
+    "attempts" integer not null default 0,
+    "next_attempt_at" timestamptz not null default now(),
+    "last_error" text,
+    "sent_at" timestamptz,
+    "created_at" timestamptz not null default now()
+  );
+
+create index IF not exists outbox_pending_next_attempt_at_idx on outbox (next_attempt_at)
+where
+  status = 'pending';
+
+---- create above / drop below ----
+drop table IF exists outbox;
\ No newline at end of file
@@ -0,0 +1,10 @@
+alter table users
+  add column "locale" varchar(16) not null default 'en';
+
+alter table participants
+  add column "locale" varchar(16) not null default 'en';
+
+---- create above / drop below ----
+alter table participants drop column IF exists "locale";
+
+alter table users drop column IF exists "locale";
\ No newline at end of file
@@ -0,0 +1,14 @@
+create table
+  IF not exists calendar_subscriptions (
+    "id" uuid primary KEY not null default gen_random_uuid(),
+    "trip_id" uuid not null,
+    "user_id" uuid not null,
+    "token_hash" bytea not null unique,
+    "created_at" timestamptz not null default now(),
+    unique (trip_id, user_id),
+    foreign KEY (trip_id) references trips (id) on update CASCADE on delete CASCADE,
+    foreign KEY (user_id) references users (id) on update CASCADE on delete CASCADE
+  );
+
+---- create above / drop below ----
+drop table IF exists calendar_subscriptions;
\ No newline at end of file
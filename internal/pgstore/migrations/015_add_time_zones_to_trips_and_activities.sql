@@ -0,0 +1,26 @@
+-- existing values hold the wall clock that was sent, whatever its offset, so
+-- they are read as UTC and the trips are set to UTC, which shows them as before
+alter table trips
+  add column "time_zone" varchar(64) not null default 'UTC';
+
+alter table trips
+  alter column "starts_at" type timestamptz using "starts_at" at time zone 'UTC',
+  alter column "ends_at" type timestamptz using "ends_at" at time zone 'UTC';
+
+alter table activities
+  add column "time_zone" varchar(64);
+
+alter table activities
+  alter column "occurs_at" type timestamptz using "occurs_at" at time zone 'UTC';
+
+---- create above / drop below ----
+alter table activities
+  alter column "occurs_at" type timestamp using "occurs_at" at time zone coalesce("time_zone", 'UTC');
+
+alter table activities drop column IF exists "time_zone";
+
+alter table trips
+  alter column "starts_at" type timestamp using "starts_at" at time zone "time_zone",
+  alter column "ends_at" type timestamp using "ends_at" at time zone "time_zone";
+
+alter table trips drop column IF exists "time_zone";
\ No newline at end of file
@@ -0,0 +1,18 @@
+alter table activities
+  add column "ends_at" timestamptz check ("ends_at" > "occurs_at"),
+  add column "location" varchar(255),
+  add column "latitude" double precision check ("latitude" between -90 and 90),
+  add column "longitude" double precision check ("longitude" between -180 and 180),
+  add column "category" varchar(16) not null default 'other' check ("category" in ('food', 'transport', 'sightseeing', 'lodging', 'leisure', 'shopping', 'other')),
+  add column "notes" text not null default '',
+  add constraint activities_coordinates_check check (("latitude" is null) = ("longitude" is null));
+
+---- create above / drop below ----
+alter table activities
+  drop constraint IF exists activities_coordinates_check,
+  drop column IF exists "notes",
+  drop column IF exists "category",
+  drop column IF exists "longitude",
+  drop column IF exists "latitude",
+  drop column IF exists "location",
+  drop column IF exists "ends_at";
\ No newline at end of file
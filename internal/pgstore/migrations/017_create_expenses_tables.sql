@@ -0,0 +1,36 @@
+-- participants who paid or owe something cannot be removed, while deleting
+-- the trip removes everything, since no action is only checked once the
+-- cascade is done
+create table
+  IF not exists expenses (
+    "id" uuid primary KEY not null default gen_random_uuid(),
+    "trip_id" uuid not null,
+    "payer_id" uuid not null,
+    "activity_id" uuid,
+    "description" varchar(255) not null,
+    "amount" bigint not null check ("amount" > 0),
+    "currency" char(3) not null,
+    "split" varchar(16) not null check ("split" in ('equal', 'shares', 'exact')),
+    "created_at" timestamptz not null default now(),
+    foreign KEY (trip_id) references trips (id) on update CASCADE on delete CASCADE,
+    foreign KEY (payer_id) references participants (id) on update CASCADE on delete no action,
+    foreign KEY (activity_id) references activities (id) on update CASCADE on delete set null
+  );
+
+create index IF not exists expenses_trip_id_created_at_id_idx on expenses (trip_id, created_at, id);
+
+create table
+  IF not exists expense_shares (
+    "expense_id" uuid not null,
+    "participant_id" uuid not null,
+    "shares" integer check ("shares" > 0),
+    "amount" bigint not null check ("amount" >= 0),
+    primary KEY (expense_id, participant_id),
+    foreign KEY (expense_id) references expenses (id) on update CASCADE on delete CASCADE,
+    foreign KEY (participant_id) references participants (id) on update CASCADE on delete no action
+  );
+
+---- create above / drop below ----
+drop table IF exists expense_shares;
+
+drop table IF exists expenses;
\ No newline at end of file
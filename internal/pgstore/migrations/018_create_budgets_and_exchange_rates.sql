@@ -0,0 +1,98 @@
+-- expense amounts were stored in minor units of their currency, so they are
+-- divided by the decimal places the API gives each currency
+alter table expenses
+  alter column "amount" type numeric(19, 4) using "amount" / power(10::numeric, case
+    when "currency" in ('ADP', 'AFN', 'ALL', 'AMD', 'BIF', 'BYR', 'CLP', 'COP', 'DJF', 'ESP', 'GNF', 'GYD', 'IDR', 'IQD', 'IRR', 'ISK', 'ITL', 'JPY', 'KMF', 'KPW', 'KRW', 'LAK', 'LBP', 'LUF', 'MGA', 'MGF', 'MMK', 'MNT', 'MRO', 'MUR', 'PKR', 'PYG', 'RSD', 'RWF', 'SLL', 'SOS', 'STD', 'SYP', 'TMM', 'TRL', 'TZS', 'UGX', 'UYI', 'UZS', 'VND', 'VUV', 'XAF', 'XOF', 'XPF', 'YER', 'ZMK', 'ZWD') then 0
+    when "currency" in ('BHD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') then 3
+    when "currency" = 'CLF' then 4
+    else 2
+  end);
+
+alter table expense_shares
+  add column "decimal_amount" numeric(19, 4);
+
+update expense_shares
+set
+  "decimal_amount" = expense_shares."amount" / power(10::numeric, case
+    when expenses."currency" in ('ADP', 'AFN', 'ALL', 'AMD', 'BIF', 'BYR', 'CLP', 'COP', 'DJF', 'ESP', 'GNF', 'GYD', 'IDR', 'IQD', 'IRR', 'ISK', 'ITL', 'JPY', 'KMF', 'KPW', 'KRW', 'LAK', 'LBP', 'LUF', 'MGA', 'MGF', 'MMK', 'MNT', 'MRO', 'MUR', 'PKR', 'PYG', 'RSD', 'RWF', 'SLL', 'SOS', 'STD', 'SYP', 'TMM', 'TRL', 'TZS', 'UGX', 'UYI', 'UZS', 'VND', 'VUV', 'XAF', 'XOF', 'XPF', 'YER', 'ZMK', 'ZWD') then 0
+    when expenses."currency" in ('BHD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') then 3
+    when expenses."currency" = 'CLF' then 4
+    else 2
+  end)
+from expenses
+where
+  expenses.id = expense_shares.expense_id;
+
+alter table expense_shares drop column "amount";
+
+alter table expense_shares rename column "decimal_amount" to "amount";
+
+alter table expense_shares
+  alter column "amount" set not null,
+  add constraint expense_shares_amount_check check ("amount" >= 0);
+
+create table
+  IF not exists budgets (
+    "trip_id" uuid primary KEY not null,
+    "currency" char(3) not null,
+    "total" numeric(19, 4) check ("total" >= 0),
+    "updated_at" timestamptz not null default now(),
+    foreign KEY (trip_id) references trips (id) on update CASCADE on delete CASCADE
+  );
+
+create table
+  IF not exists budget_categories (
+    "trip_id" uuid not null,
+    "category" varchar(16) not null check ("category" in ('food', 'transport', 'sightseeing', 'lodging', 'leisure', 'shopping', 'other')),
+    "amount" numeric(19, 4) not null check ("amount" >= 0),
+    primary KEY (trip_id, category),
+    foreign KEY (trip_id) references budgets (trip_id) on update CASCADE on delete CASCADE
+  );
+
+-- rates hold from valid_on until a later one of the same pair
+create table
+  IF not exists exchange_rates (
+    "base" char(3) not null,
+    "quote" char(3) not null,
+    "valid_on" date not null,
+    "rate" numeric(24, 12) not null check ("rate" > 0),
+    primary KEY (base, quote, valid_on)
+  );
+
+---- create above / drop below ----
+drop table IF exists exchange_rates;
+
+drop table IF exists budget_categories;
+
+drop table IF exists budgets;
+
+alter table expense_shares
+  add column "minor_amount" bigint;
+
+update expense_shares
+set
+  "minor_amount" = expense_shares."amount" * power(10::numeric, case
+    when expenses."currency" in ('ADP', 'AFN', 'ALL', 'AMD', 'BIF', 'BYR', 'CLP', 'COP', 'DJF', 'ESP', 'GNF', 'GYD', 'IDR', 'IQD', 'IRR', 'ISK', 'ITL', 'JPY', 'KMF', 'KPW', 'KRW', 'LAK', 'LBP', 'LUF', 'MGA', 'MGF', 'MMK', 'MNT', 'MRO', 'MUR', 'PKR', 'PYG', 'RSD', 'RWF', 'SLL', 'SOS', 'STD', 'SYP', 'TMM', 'TRL', 'TZS', 'UGX', 'UYI', 'UZS', 'VND', 'VUV', 'XAF', 'XOF', 'XPF', 'YER', 'ZMK', 'ZWD') then 0
+    when expenses."currency" in ('BHD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') then 3
+    when expenses."currency" = 'CLF' then 4
+    else 2
+  end)
+from expenses
+where
+  expenses.id = expense_shares.expense_id;
+
+alter table expense_shares drop column "amount";
+
+alter table expense_shares rename column "minor_amount" to "amount";
+
+alter table expense_shares
+  alter column "amount" set not null,
+  add constraint expense_shares_amount_check check ("amount" >= 0);
+
+alter table expenses
+  alter column "amount" type bigint using "amount" * power(10::numeric, case
+    when "currency" in ('ADP', 'AFN', 'ALL', 'AMD', 'BIF', 'BYR', 'CLP', 'COP', 'DJF', 'ESP', 'GNF', 'GYD', 'IDR', 'IQD', 'IRR', 'ISK', 'ITL', 'JPY', 'KMF', 'KPW', 'KRW', 'LAK', 'LBP', 'LUF', 'MGA', 'MGF', 'MMK', 'MNT', 'MRO', 'MUR', 'PKR', 'PYG', 'RSD', 'RWF', 'SLL', 'SOS', 'STD', 'SYP', 'TMM', 'TRL', 'TZS', 'UGX', 'UYI', 'UZS', 'VND', 'VUV', 'XAF', 'XOF', 'XPF', 'YER', 'ZMK', 'ZWD') then 0
+    when "currency" in ('BHD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') then 3
+    when "currency" = 'CLF' then 4
+    else 2
+  end);
@@ -0,0 +1,32 @@
+-- participants answer their invitation instead of just confirming it, and are
+-- removed by marking them so, which keeps the expenses they took part in
+alter table participants
+  add column "name" varchar(255),
+  add column "status" varchar(16) not null default 'invited' check ("status" in ('invited', 'accepted', 'declined', 'maybe', 'removed')),
+  add column "responded_at" timestamptz,
+  add column "removed_at" timestamptz;
+
+update participants
+set
+  "status" = 'accepted',
+  "responded_at" = "created_at"
+where
+  "is_confirmed";
+
+alter table participants drop column "is_confirmed";
+
+---- create above / drop below ----
+alter table participants
+  add column "is_confirmed" boolean not null default false;
+
+update participants
+set
+  "is_confirmed" = true
+where
+  "status" = 'accepted';
+
+alter table participants
+  drop column IF exists "removed_at",
+  drop column IF exists "responded_at",
+  drop column IF exists "status",
+  drop column IF exists "name";
@@ -0,0 +1,102 @@
+-- owners take part in their trips like everyone else. A participant invited
+-- with the owner email becomes the owner, and trips without one get a new
+-- participant for their owner.
+update participants
+set
+  "role" = 'owner',
+  "status" = 'accepted',
+  "responded_at" = coalesce(participants."responded_at", now()),
+  "removed_at" = null
+where
+  participants.id in (
+    select distinct on (p.trip_id) p.id
+    from participants p
+    join trips on trips.id = p.trip_id
+    where lower(p.email) = lower(trips.owner_email)
+    order by p.trip_id, p.created_at, p.id
+  );
+
+insert into
+  participants ("trip_id", "email", "name", "role", "status", "responded_at", "locale")
+select trips.id, trips.owner_email, trips.owner_name, 'owner', 'accepted', now(), users.locale
+from trips
+join users on users.email = trips.owner_email
+where
+  not exists (
+    select 1
+    from participants
+    where
+      participants.trip_id = trips.id
+      and participants.role = 'owner'
+  );
+
+create unique index IF not exists participants_trip_id_owner_idx on participants (trip_id) where "role" = 'owner';
+
+-- the owner is kept on the trip and as the role of one of its participants,
+-- which must name the same person. They are compared when the transaction
+-- that changes either of them commits, so both can be changed one after the
+-- other, as when ownership is transferred.
+create or replace function check_trip_owner() returns trigger as $$
+declare
+  checked_trip_id uuid;
+begin
+  if TG_TABLE_NAME = 'trips' then
+    checked_trip_id := NEW.id;
+  elsif TG_OP = 'DELETE' then
+    checked_trip_id := OLD.trip_id;
+  else
+    checked_trip_id := NEW.trip_id;
+  end if;
+
+  if exists (
+    select 1
+    from trips
+    where
+      trips.id = checked_trip_id
+      and not exists (
+        select 1
+        from participants
+        where
+          participants.trip_id = trips.id
+          and participants.role = 'owner'
+          and lower(participants.email) = lower(trips.owner_email)
+      )
+  ) then
+    raise exception 'owner of trip % is not its owner participant', checked_trip_id
+      using errcode = 'check_violation';
+  end if;
+
+  return null;
+end;
+$$ language plpgsql;
+
+create constraint trigger trips_owner_check
+after insert or update of owner_email on trips
+deferrable initially deferred
+for each row execute function check_trip_owner();
+
+create constraint trigger participants_owner_check
+after insert or update of role, email, trip_id or delete on participants
+deferrable initially deferred
+for each row execute function check_trip_owner();
+
+---- create above / drop below ----
+drop trigger IF exists participants_owner_check on participants;
+
+drop trigger IF exists trips_owner_check on trips;
+
+drop function IF exists check_trip_owner();
+
+drop index IF exists participants_trip_id_owner_idx;
+
+delete from participants
+where
+  "role" = 'owner'
+  and not exists (select 1 from expenses where expenses.payer_id = participants.id)
+  and not exists (select 1 from expense_shares where expense_shares.participant_id = participants.id);
+
+update participants
+set
+  "role" = 'editor'
+where
+  "role" = 'owner';
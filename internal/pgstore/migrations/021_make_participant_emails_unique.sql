@@ -0,0 +1,59 @@
+-- an address takes part in a trip once whatever its case. Participants
+-- invited more than once are merged into the one that owns the trip, or else
+-- the one that is furthest along, and their expenses move with them.
+create temporary table participant_duplicates on commit drop as
+select p.id, first_value(p.id) over (
+    partition by p.trip_id, lower(p.email)
+    order by
+      p.role = 'owner' desc,
+      p.status = 'accepted' desc,
+      p.status <> 'removed' desc,
+      p.created_at,
+      p.id
+  ) as keeper_id
+from participants p;
+
+delete from participant_duplicates
+where
+  id = keeper_id;
+
+update expenses
+set
+  "payer_id" = participant_duplicates.keeper_id
+from participant_duplicates
+where
+  expenses.payer_id = participant_duplicates.id;
+
+insert into
+  expense_shares ("expense_id", "participant_id", "shares", "amount")
+select
+  expense_shares.expense_id,
+  participant_duplicates.keeper_id,
+  sum(expense_shares."shares")::integer,
+  sum(expense_shares."amount")
+from expense_shares
+join participant_duplicates on participant_duplicates.id = expense_shares.participant_id
+group by expense_shares.expense_id, participant_duplicates.keeper_id
+on conflict ("expense_id", "participant_id") do update
+set
+  "shares" = expense_shares."shares" + excluded."shares",
+  "amount" = expense_shares."amount" + excluded."amount";
+
+delete from expense_shares
+using participant_duplicates
+where
+  expense_shares.participant_id = participant_duplicates.id;
+
+delete from participants
+using participant_duplicates
+where
+  participants.id = participant_duplicates.id;
+
+drop index IF exists participants_trip_id_lower_email_idx;
+
+create unique index IF not exists participants_trip_id_lower_email_key on participants (trip_id, lower(email));
+
+---- create above / drop below ----
+drop index IF exists participants_trip_id_lower_email_key;
+
+create index IF not exists participants_trip_id_lower_email_idx on participants (trip_id, lower(email));
@@ -0,0 +1,59 @@
+-- invitations nobody answers are reminded a few times and then expire. What
+-- was sent is kept here so a restart does not send it again: the reminders of
+-- each invitation, counted from when it was last sent, and the start date of
+-- the trip the owner was last told about.
+alter table participants
+  drop constraint IF exists participants_status_check,
+  add constraint participants_status_check check ("status" in ('invited', 'accepted', 'declined', 'maybe', 'removed', 'expired'));
+
+create table
+  IF not exists invitation_reminders (
+    "participant_id" uuid primary KEY not null,
+    "invited_at" timestamptz not null,
+    "reminders_sent" integer not null default 0,
+    "reminded_at" timestamptz,
+    foreign KEY (participant_id) references participants (id) on update CASCADE on delete CASCADE
+  );
+
+create table
+  IF not exists pending_summaries (
+    "trip_id" uuid primary KEY not null,
+    "starts_at" timestamptz not null,
+    "sent_at" timestamptz not null default now(),
+    foreign KEY (trip_id) references trips (id) on update CASCADE on delete CASCADE
+  );
+
+-- reminders and deadlines count from when the invitation e-mail was sent,
+-- which is written here along with every invitation from now on. Those still
+-- unanswered were sent at some point before, and count from now.
+insert into
+  invitation_reminders ("participant_id", "invited_at")
+select
+  participants."id",
+  now()
+from participants
+join trips on trips.id = participants.trip_id
+where
+  participants."status" = 'invited'
+  and participants."role" <> 'owner'
+  and trips."is_confirmed"
+on conflict ("participant_id") do nothing;
+
+create index IF not exists participants_invited_idx on participants (trip_id) where "status" = 'invited';
+
+---- create above / drop below ----
+drop index IF exists participants_invited_idx;
+
+drop table IF exists pending_summaries;
+
+drop table IF exists invitation_reminders;
+
+update participants
+set
+  "status" = 'invited'
+where
+  "status" = 'expired';
+
+alter table participants
+  drop constraint IF exists participants_status_check,
+  add constraint participants_status_check check ("status" in ('invited', 'accepted', 'declined', 'maybe', 'removed'));
@@ -0,0 +1,24 @@
+-- the digests of each trip are sent once a day at most, so each one sent is
+-- kept with the day of the trip it was sent on. Participants who turn them
+-- off are listed apart, and receive everything else as before.
+create table
+  IF not exists trip_digests (
+    "trip_id" uuid not null,
+    "kind" varchar(16) not null check ("kind" in ('daily', 'countdown')),
+    "sent_on" date not null,
+    "sent_at" timestamptz not null default now(),
+    primary KEY (trip_id, kind, sent_on),
+    foreign KEY (trip_id) references trips (id) on update CASCADE on delete CASCADE
+  );
+
+create table
+  IF not exists digest_opt_outs (
+    "participant_id" uuid primary KEY not null,
+    "created_at" timestamptz not null default now(),
+    foreign KEY (participant_id) references participants (id) on update CASCADE on delete CASCADE
+  );
+
+---- create above / drop below ----
+drop table IF exists digest_opt_outs;
+
+drop table IF exists trip_digests;
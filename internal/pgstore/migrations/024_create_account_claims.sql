@@ -0,0 +1,26 @@
+-- placeholder accounts are only claimed through a link sent to their email.
+-- What was signed up with waits here until the link is opened.
+alter table confirmation_tokens
+  drop constraint IF exists confirmation_tokens_purpose_check,
+  add constraint confirmation_tokens_purpose_check check ("purpose" in ('trip', 'participant', 'account'));
+
+create table
+  IF not exists account_claims (
+    "token_id" uuid primary KEY not null,
+    "name" varchar(255) not null,
+    "password_hash" varchar(255) not null,
+    "locale" varchar(16) not null,
+    "created_at" timestamptz not null default now(),
+    foreign KEY (token_id) references confirmation_tokens (id) on update CASCADE on delete CASCADE
+  );
+
+---- create above / drop below ----
+drop table IF exists account_claims;
+
+delete from confirmation_tokens
+where
+  "purpose" = 'account';
+
+alter table confirmation_tokens
+  drop constraint IF exists confirmation_tokens_purpose_check,
+  add constraint confirmation_tokens_purpose_check check ("purpose" in ('trip', 'participant'));
@@ -9,33 +9,166 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccountClaim struct {
+	TokenID      uuid.UUID          `db:"token_id" json:"token_id"`
+	Name         string             `db:"name" json:"name"`
+	PasswordHash string             `db:"password_hash" json:"password_hash"`
+	Locale       string             `db:"locale" json:"locale"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type Activity struct {
-	ID       uuid.UUID        `db:"id" json:"id"`
-	TripID   uuid.UUID        `db:"trip_id" json:"trip_id"`
-	Title    string           `db:"title" json:"title"`
-	OccursAt pgtype.Timestamp `db:"occurs_at" json:"occurs_at"`
+	ID        uuid.UUID          `db:"id" json:"id"`
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Title     string             `db:"title" json:"title"`
+	OccursAt  pgtype.Timestamptz `db:"occurs_at" json:"occurs_at"`
+	TimeZone  pgtype.Text        `db:"time_zone" json:"time_zone"`
+	EndsAt    pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	Location  pgtype.Text        `db:"location" json:"location"`
+	Latitude  pgtype.Float8      `db:"latitude" json:"latitude"`
+	Longitude pgtype.Float8      `db:"longitude" json:"longitude"`
+	Category  string             `db:"category" json:"category"`
+	Notes     string             `db:"notes" json:"notes"`
+}
+
+type Budget struct {
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Currency  string             `db:"currency" json:"currency"`
+	Total     pgtype.Numeric     `db:"total" json:"total"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type BudgetCategory struct {
+	TripID   uuid.UUID      `db:"trip_id" json:"trip_id"`
+	Category string         `db:"category" json:"category"`
+	Amount   pgtype.Numeric `db:"amount" json:"amount"`
+}
+
+type CalendarSubscription struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	UserID    uuid.UUID          `db:"user_id" json:"user_id"`
+	TokenHash []byte             `db:"token_hash" json:"token_hash"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type ConfirmationToken struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	Purpose   string             `db:"purpose" json:"purpose"`
+	SubjectID uuid.UUID          `db:"subject_id" json:"subject_id"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `db:"used_at" json:"used_at"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type DigestOptOut struct {
+	ParticipantID uuid.UUID          `db:"participant_id" json:"participant_id"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type ExchangeRate struct {
+	Base    string         `db:"base" json:"base"`
+	Quote   string         `db:"quote" json:"quote"`
+	ValidOn pgtype.Date    `db:"valid_on" json:"valid_on"`
+	Rate    pgtype.Numeric `db:"rate" json:"rate"`
+}
+
+type Expense struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	TripID      uuid.UUID          `db:"trip_id" json:"trip_id"`
+	PayerID     uuid.UUID          `db:"payer_id" json:"payer_id"`
+	ActivityID  pgtype.UUID        `db:"activity_id" json:"activity_id"`
+	Description string             `db:"description" json:"description"`
+	Amount      pgtype.Numeric     `db:"amount" json:"amount"`
+	Currency    string             `db:"currency" json:"currency"`
+	Split       string             `db:"split" json:"split"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type ExpenseShare struct {
+	ExpenseID     uuid.UUID      `db:"expense_id" json:"expense_id"`
+	ParticipantID uuid.UUID      `db:"participant_id" json:"participant_id"`
+	Shares        pgtype.Int4    `db:"shares" json:"shares"`
+	Amount        pgtype.Numeric `db:"amount" json:"amount"`
+}
+
+type InvitationReminder struct {
+	ParticipantID uuid.UUID          `db:"participant_id" json:"participant_id"`
+	InvitedAt     pgtype.Timestamptz `db:"invited_at" json:"invited_at"`
+	RemindersSent int32              `db:"reminders_sent" json:"reminders_sent"`
+	RemindedAt    pgtype.Timestamptz `db:"reminded_at" json:"reminded_at"`
 }
 
 type Link struct {
-	ID     uuid.UUID `db:"id" json:"id"`
-	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
-	Title  string    `db:"title" json:"title"`
-	Url    string    `db:"url" json:"url"`
+	ID        uuid.UUID          `db:"id" json:"id"`
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Title     string             `db:"title" json:"title"`
+	Url       string             `db:"url" json:"url"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type Outbox struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	Kind          string             `db:"kind" json:"kind"`
+	Payload       []byte             `db:"payload" json:"payload"`
+	Status        string             `db:"status" json:"status"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	SentAt        pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
 type Participant struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	TripID      uuid.UUID `db:"trip_id" json:"trip_id"`
-	Email       string    `db:"email" json:"email"`
-	IsConfirmed bool      `db:"is_confirmed" json:"is_confirmed"`
+	ID          uuid.UUID          `db:"id" json:"id"`
+	TripID      uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Email       string             `db:"email" json:"email"`
+	Role        string             `db:"role" json:"role"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Locale      string             `db:"locale" json:"locale"`
+	Name        pgtype.Text        `db:"name" json:"name"`
+	Status      string             `db:"status" json:"status"`
+	RespondedAt pgtype.Timestamptz `db:"responded_at" json:"responded_at"`
+	RemovedAt   pgtype.Timestamptz `db:"removed_at" json:"removed_at"`
+}
+
+type PendingSummary struct {
+	TripID   uuid.UUID          `db:"trip_id" json:"trip_id"`
+	StartsAt pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	SentAt   pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+}
+
+type Session struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	UserID    uuid.UUID          `db:"user_id" json:"user_id"`
+	TokenHash []byte             `db:"token_hash" json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
 type Trip struct {
-	ID          uuid.UUID        `db:"id" json:"id"`
-	Destination string           `db:"destination" json:"destination"`
-	OwnerEmail  string           `db:"owner_email" json:"owner_email"`
-	OwnerName   string           `db:"owner_name" json:"owner_name"`
-	IsConfirmed bool             `db:"is_confirmed" json:"is_confirmed"`
-	StartsAt    pgtype.Timestamp `db:"starts_at" json:"starts_at"`
-	EndsAt      pgtype.Timestamp `db:"ends_at" json:"ends_at"`
+	ID          uuid.UUID          `db:"id" json:"id"`
+	Destination string             `db:"destination" json:"destination"`
+	OwnerEmail  string             `db:"owner_email" json:"owner_email"`
+	OwnerName   string             `db:"owner_name" json:"owner_name"`
+	IsConfirmed bool               `db:"is_confirmed" json:"is_confirmed"`
+	StartsAt    pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt      pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	TimeZone    string             `db:"time_zone" json:"time_zone"`
+}
+
+type TripDigest struct {
+	TripID uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Kind   string             `db:"kind" json:"kind"`
+	SentOn pgtype.Date        `db:"sent_on" json:"sent_on"`
+	SentAt pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+}
+
+type User struct {
+	ID           uuid.UUID          `db:"id" json:"id"`
+	Email        string             `db:"email" json:"email"`
+	Name         string             `db:"name" json:"name"`
+	PasswordHash string             `db:"password_hash" json:"password_hash"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Locale       string             `db:"locale" json:"locale"`
 }
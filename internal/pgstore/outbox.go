@@ -0,0 +1,195 @@
+package pgstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"planner-go/internal/magiclink"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Kinds of the messages written to the outbox. Each kind has its own payload
+// type below.
+const (
+	OutboxTripCreated        = "trip_created"
+	OutboxParticipantInvited = "participant_invited"
+	OutboxTripDeleted        = "trip_deleted"
+	OutboxActivityDeleted    = "activity_deleted"
+	OutboxLinkDeleted        = "link_deleted"
+	OutboxParticipantRemoved = "participant_removed"
+	OutboxInvitationReminder = "invitation_reminder"
+	OutboxPendingInvitations = "pending_invitations"
+	OutboxDailyDigest        = "daily_digest"
+	OutboxTripCountdown      = "trip_countdown"
+	OutboxAccountClaim       = "account_claim"
+)
+
+// TripMessage is the payload of OutboxPendingInvitations.
+type TripMessage struct {
+	TripID uuid.UUID `json:"trip_id"`
+}
+
+// TripCreatedMessage is the payload of OutboxTripCreated. Token is that of the
+// confirmation link, created along with the message.
+type TripCreatedMessage struct {
+	TripID uuid.UUID `json:"trip_id"`
+	Token  Token     `json:"token"`
+}
+
+// ParticipantInvitedMessage is the payload of OutboxParticipantInvited and
+// OutboxInvitationReminder. Token is that of the confirmation link, created
+// along with the message.
+type ParticipantInvitedMessage struct {
+	TripID        uuid.UUID `json:"trip_id"`
+	ParticipantID uuid.UUID `json:"participant_id"`
+	Token         Token     `json:"token"`
+}
+
+// Kinds of the digests kept in trip_digests, which are sent once a day at
+// most.
+const (
+	DigestDaily     = "daily"
+	DigestCountdown = "countdown"
+)
+
+// DailyDigestMessage is the payload of OutboxDailyDigest. Day is the date of
+// the activities in the time zone of the trip, as YYYY-MM-DD.
+type DailyDigestMessage struct {
+	TripID uuid.UUID `json:"trip_id"`
+	Day    string    `json:"day"`
+}
+
+// TripCountdownMessage is the payload of OutboxTripCountdown.
+type TripCountdownMessage struct {
+	TripID uuid.UUID `json:"trip_id"`
+	Days   int32     `json:"days"`
+}
+
+// Token is a confirmation token created along with the message that carries
+// its link, so every attempt to deliver the message sends the same link.
+type Token struct {
+	ID        uuid.UUID `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AccountClaimMessage is the payload of OutboxAccountClaim. The e-mail goes in
+// the locale the account was signed up with.
+type AccountClaimMessage struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Locale string    `json:"locale"`
+	Token  Token     `json:"token"`
+}
+
+// The payloads of deletions carry the deleted rows themselves, since they are
+// gone by the time the message is delivered.
+
+// TripDeletedMessage is the payload of OutboxTripDeleted.
+type TripDeletedMessage struct {
+	Trip         Trip          `json:"trip"`
+	Participants []Participant `json:"participants"`
+}
+
+// ActivityDeletedMessage is the payload of OutboxActivityDeleted.
+type ActivityDeletedMessage struct {
+	Activity Activity `json:"activity"`
+}
+
+// LinkDeletedMessage is the payload of OutboxLinkDeleted.
+type LinkDeletedMessage struct {
+	Link Link `json:"link"`
+}
+
+// ParticipantRemovedMessage is the payload of OutboxParticipantRemoved.
+type ParticipantRemovedMessage struct {
+	Trip        Trip        `json:"trip"`
+	Participant Participant `json:"participant"`
+}
+
+// TokenTTL is how long the confirmation links sent by e-mail stay valid.
+const TokenTTL = 7 * 24 * time.Hour
+
+// issueToken creates a confirmation token for subjectId that expires after
+// ttl.
+func (q *Queries) issueToken(ctx context.Context, purpose string, subjectId uuid.UUID, ttl time.Duration) (Token, error) {
+	expiresAt := time.Now().Add(ttl)
+
+	id, err := q.CreateConfirmationToken(ctx, CreateConfirmationTokenParams{
+		Purpose:   purpose,
+		SubjectID: subjectId,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return Token{}, err
+	}
+
+	return Token{ID: id, ExpiresAt: expiresAt}, nil
+}
+
+// enqueueInvitation queues the e-mail inviting a participant and starts the
+// clock of its reminders and deadline, which count from the last invitation
+// that was sent.
+func (q *Queries) enqueueInvitation(ctx context.Context, tripId, participantId uuid.UUID) error {
+	if err := q.ResetInvitationReminders(ctx, participantId); err != nil {
+		return fmt.Errorf("pgstore: failed to reset reminders for %s message: %w", OutboxParticipantInvited, err)
+	}
+
+	return q.enqueueParticipantMessage(ctx, OutboxParticipantInvited, tripId, participantId)
+}
+
+// enqueueParticipantMessage queues the e-mail kind asking a participant to
+// answer an invitation, along with the token of its confirmation link.
+func (q *Queries) enqueueParticipantMessage(ctx context.Context, kind string, tripId, participantId uuid.UUID) error {
+	token, err := q.issueToken(ctx, magiclink.PurposeParticipant, participantId, TokenTTL)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to create token for %s message: %w", kind, err)
+	}
+
+	return q.enqueue(ctx, kind, ParticipantInvitedMessage{
+		TripID:        tripId,
+		ParticipantID: participantId,
+		Token:         token,
+	})
+}
+
+// enqueueTripInvitations queues the invitation of each participant of a trip
+// that was just confirmed, one message each, so an address the mail server
+// refuses does not make the others receive theirs again. Those who declined,
+// were removed or let the invitation expire are not invited again, and the
+// owner got a message of their own.
+func (q *Queries) enqueueTripInvitations(ctx context.Context, tripId uuid.UUID) error {
+	participants, err := q.GetParticipants(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to get participants to invite: %w", err)
+	}
+
+	for _, participant := range participants {
+		if participant.Status == RSVPDeclined || participant.Status == RSVPExpired ||
+			participant.Removed() || participant.Owns() {
+			continue
+		}
+
+		if err := q.enqueueInvitation(ctx, tripId, participant.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueue writes a message to the outbox. It is meant to be called on the
+// Queries of a transaction, so the message is only kept if the change that
+// caused it is committed.
+func (q *Queries) enqueue(ctx context.Context, kind string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to encode %s message: %w", kind, err)
+	}
+
+	return q.InsertOutboxMessage(ctx, InsertOutboxMessageParams{
+		Kind:    kind,
+		Payload: b,
+	})
+}
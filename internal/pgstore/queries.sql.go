@@ -12,110 +12,217 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const confirmParticipant = `-- name: ConfirmParticipant :exec
-select
-    "id", 
-    "trip_id", 
-    "email", 
-    "is_confirmed"
-from participants
-where
-    id = $1
+const addTripOwner = `-- name: AddTripOwner :exec
+insert into participants
+    ( "trip_id", "email", "name", "locale", "role", "status", "responded_at" ) values
+    ( $1, $2, $3, $4, 'owner', 'accepted', now() )
 `
 
-func (q *Queries) ConfirmParticipant(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, confirmParticipant, id)
+type AddTripOwnerParams struct {
+	TripID uuid.UUID   `db:"trip_id" json:"trip_id"`
+	Email  string      `db:"email" json:"email"`
+	Name   pgtype.Text `db:"name" json:"name"`
+	Locale string      `db:"locale" json:"locale"`
+}
+
+func (q *Queries) AddTripOwner(ctx context.Context, arg AddTripOwnerParams) error {
+	_, err := q.db.Exec(ctx, addTripOwner,
+		arg.TripID,
+		arg.Email,
+		arg.Name,
+		arg.Locale,
+	)
 	return err
 }
 
-const createActivity = `-- name: CreateActivity :one
-insert into activities
-    ( "trip_id", "title", "occurs_at" ) values
-    ( $1, $2, $3 )
-returning "id"
+const claimAccount = `-- name: ClaimAccount :execrows
+update users
+set
+    "name" = account_claims."name",
+    "password_hash" = account_claims."password_hash",
+    "locale" = account_claims."locale"
+from account_claims
+where
+    account_claims.token_id = $1
+    and users.id = $2
+    and users."password_hash" = ''
 `
 
-type CreateActivityParams struct {
-	TripID   uuid.UUID        `db:"trip_id" json:"trip_id"`
-	Title    string           `db:"title" json:"title"`
-	OccursAt pgtype.Timestamp `db:"occurs_at" json:"occurs_at"`
+type ClaimAccountParams struct {
+	TokenID uuid.UUID `db:"token_id" json:"token_id"`
+	UserID  uuid.UUID `db:"user_id" json:"user_id"`
 }
 
-func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, createActivity, arg.TripID, arg.Title, arg.OccursAt)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+func (q *Queries) ClaimAccount(ctx context.Context, arg ClaimAccountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimAccount, arg.TokenID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const createTripLink = `-- name: CreateTripLink :one
-insert into links
-    ( "trip_id", "title", "url" ) values
-    ( $1, $2, $3 )
-returning "id"
+const claimDailyDigests = `-- name: ClaimDailyDigests :many
+select
+    trips."id",
+    (now() at time zone trips."time_zone")::date as sent_on
+from trips
+where
+    trips."is_confirmed"
+    and (now() at time zone trips."time_zone")::time >= $1::time
+    and (now() at time zone trips."time_zone")::date + 1
+        between (trips."starts_at" at time zone trips."time_zone")::date
+        and (trips."ends_at" at time zone trips."time_zone")::date
+    and not exists (
+        select 1
+        from trip_digests
+        where
+            trip_digests.trip_id = trips.id
+            and trip_digests."kind" = 'daily'
+            and trip_digests."sent_on" = (now() at time zone trips."time_zone")::date
+    )
+order by trips."id"
+limit $2
+for update of trips skip locked
 `
 
-type CreateTripLinkParams struct {
-	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
-	Title  string    `db:"title" json:"title"`
-	Url    string    `db:"url" json:"url"`
+type ClaimDailyDigestsParams struct {
+	SendAfter pgtype.Time `db:"send_after" json:"send_after"`
+	RowLimit  int32       `db:"row_limit" json:"row_limit"`
 }
 
-func (q *Queries) CreateTripLink(ctx context.Context, arg CreateTripLinkParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, createTripLink, arg.TripID, arg.Title, arg.Url)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+type ClaimDailyDigestsRow struct {
+	ID     uuid.UUID   `db:"id" json:"id"`
+	SentOn pgtype.Date `db:"sent_on" json:"sent_on"`
 }
 
-const getParticipant = `-- name: GetParticipant :one
+func (q *Queries) ClaimDailyDigests(ctx context.Context, arg ClaimDailyDigestsParams) ([]ClaimDailyDigestsRow, error) {
+	rows, err := q.db.Query(ctx, claimDailyDigests, arg.SendAfter, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimDailyDigestsRow
+	for rows.Next() {
+		var i ClaimDailyDigestsRow
+		if err := rows.Scan(&i.ID, &i.SentOn); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimInvitationReminders = `-- name: ClaimInvitationReminders :many
 select
-    "id", 
-    "trip_id", 
-    "email", 
-    "is_confirmed"
+    participants."id",
+    participants."trip_id"
 from participants
+join trips on trips.id = participants.trip_id
+join invitation_reminders on invitation_reminders.participant_id = participants.id
 where
-    id = $1
+    participants."status" = 'invited'
+    and participants."role" <> 'owner'
+    and trips."is_confirmed"
+    and trips."starts_at" > now()
+    and invitation_reminders."reminders_sent" = $1
+    and invitation_reminders."invited_at" <= $2
+    and coalesce(invitation_reminders."reminded_at", invitation_reminders."invited_at") <= $3
+order by participants."id"
+limit $4
+for update of participants skip locked
 `
 
-func (q *Queries) GetParticipant(ctx context.Context, id uuid.UUID) (Participant, error) {
-	row := q.db.QueryRow(ctx, getParticipant, id)
-	var i Participant
-	err := row.Scan(
-		&i.ID,
-		&i.TripID,
-		&i.Email,
-		&i.IsConfirmed,
+type ClaimInvitationRemindersParams struct {
+	RemindersSent  int32              `db:"reminders_sent" json:"reminders_sent"`
+	InvitedBefore  pgtype.Timestamptz `db:"invited_before" json:"invited_before"`
+	RemindedBefore pgtype.Timestamptz `db:"reminded_before" json:"reminded_before"`
+	RowLimit       int32              `db:"row_limit" json:"row_limit"`
+}
+
+type ClaimInvitationRemindersRow struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) ClaimInvitationReminders(ctx context.Context, arg ClaimInvitationRemindersParams) ([]ClaimInvitationRemindersRow, error) {
+	rows, err := q.db.Query(ctx, claimInvitationReminders,
+		arg.RemindersSent,
+		arg.InvitedBefore,
+		arg.RemindedBefore,
+		arg.RowLimit,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimInvitationRemindersRow
+	for rows.Next() {
+		var i ClaimInvitationRemindersRow
+		if err := rows.Scan(&i.ID, &i.TripID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getParticipants = `-- name: GetParticipants :many
-select
-    "id", 
-    "trip_id", 
-    "email", 
-    "is_confirmed"
-from participants
+const claimOutboxMessages = `-- name: ClaimOutboxMessages :many
+update outbox
+set
+    "next_attempt_at" = $1
 where
-    trip_id = $1
+    id in (
+        select "id"
+        from outbox
+        where
+            status = 'pending'
+            and next_attempt_at <= now()
+        order by "next_attempt_at", "id"
+        limit $2
+        for update skip locked
+    )
+returning
+    "id",
+    "kind",
+    "payload",
+    "status",
+    "attempts",
+    "next_attempt_at",
+    "last_error",
+    "sent_at",
+    "created_at"
 `
 
-func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Participant, error) {
-	rows, err := q.db.Query(ctx, getParticipants, tripID)
+type ClaimOutboxMessagesParams struct {
+	LeasedUntil pgtype.Timestamptz `db:"leased_until" json:"leased_until"`
+	BatchSize   int32              `db:"batch_size" json:"batch_size"`
+}
+
+func (q *Queries) ClaimOutboxMessages(ctx context.Context, arg ClaimOutboxMessagesParams) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, claimOutboxMessages, arg.LeasedUntil, arg.BatchSize)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Participant
+	var items []Outbox
 	for rows.Next() {
-		var i Participant
+		var i Outbox
 		if err := rows.Scan(
 			&i.ID,
-			&i.TripID,
-			&i.Email,
-			&i.IsConfirmed,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.SentAt,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -127,61 +234,54 @@ func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Part
 	return items, nil
 }
 
-const getTrip = `-- name: GetTrip :one
+const claimPendingSummaries = `-- name: ClaimPendingSummaries :many
 select
-    "id", 
-    "destination", 
-    "owner_email", 
-    "owner_name", 
-    "is_confirmed", 
-    "starts_at", 
-    "ends_at"
+    trips."id",
+    trips."starts_at"
 from trips
 where
-    id = $1
+    trips."is_confirmed"
+    and trips."starts_at" > now()
+    and trips."starts_at" <= $1
+    and not exists (
+        select 1
+        from pending_summaries
+        where
+            pending_summaries.trip_id = trips.id
+            and pending_summaries."starts_at" = trips."starts_at"
+    )
+    and exists (
+        select 1
+        from participants
+        where
+            participants.trip_id = trips.id
+            and participants."status" in ('invited', 'maybe')
+    )
+order by trips."starts_at", trips."id"
+limit $2
+for update of trips skip locked
 `
 
-func (q *Queries) GetTrip(ctx context.Context, id uuid.UUID) (Trip, error) {
-	row := q.db.QueryRow(ctx, getTrip, id)
-	var i Trip
-	err := row.Scan(
-		&i.ID,
-		&i.Destination,
-		&i.OwnerEmail,
-		&i.OwnerName,
-		&i.IsConfirmed,
-		&i.StartsAt,
-		&i.EndsAt,
-	)
-	return i, err
+type ClaimPendingSummariesParams struct {
+	StartsBefore pgtype.Timestamptz `db:"starts_before" json:"starts_before"`
+	RowLimit     int32              `db:"row_limit" json:"row_limit"`
 }
 
-const getTripActivities = `-- name: GetTripActivities :many
-select
-    "id", 
-    "trip_id", 
-    "title", 
-    "occurs_at"
-from activities
-where
-    trip_id = $1
-`
+type ClaimPendingSummariesRow struct {
+	ID       uuid.UUID          `db:"id" json:"id"`
+	StartsAt pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+}
 
-func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Activity, error) {
-	rows, err := q.db.Query(ctx, getTripActivities, tripID)
+func (q *Queries) ClaimPendingSummaries(ctx context.Context, arg ClaimPendingSummariesParams) ([]ClaimPendingSummariesRow, error) {
+	rows, err := q.db.Query(ctx, claimPendingSummaries, arg.StartsBefore, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Activity
+	var items []ClaimPendingSummariesRow
 	for rows.Next() {
-		var i Activity
-		if err := rows.Scan(
-			&i.ID,
-			&i.TripID,
-			&i.Title,
-			&i.OccursAt,
-		); err != nil {
+		var i ClaimPendingSummariesRow
+		if err := rows.Scan(&i.ID, &i.StartsAt); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -192,32 +292,51 @@ func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Ac
 	return items, nil
 }
 
-const getTripLinks = `-- name: GetTripLinks :many
+const claimTripCountdowns = `-- name: ClaimTripCountdowns :many
 select
-    "id", 
-    "trip_id", 
-    "title", 
-    "url"
-from links
+    trips."id",
+    (now() at time zone trips."time_zone")::date as sent_on,
+    ((trips."starts_at" at time zone trips."time_zone")::date - (now() at time zone trips."time_zone")::date)::integer as days
+from trips
 where
-    trip_id = $1
+    trips."is_confirmed"
+    and (now() at time zone trips."time_zone")::time >= $1::time
+    and (trips."starts_at" at time zone trips."time_zone")::date - (now() at time zone trips."time_zone")::date = any($2::integer[])
+    and not exists (
+        select 1
+        from trip_digests
+        where
+            trip_digests.trip_id = trips.id
+            and trip_digests."kind" = 'countdown'
+            and trip_digests."sent_on" = (now() at time zone trips."time_zone")::date
+    )
+order by trips."id"
+limit $3
+for update of trips skip locked
 `
 
-func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, error) {
-	rows, err := q.db.Query(ctx, getTripLinks, tripID)
+type ClaimTripCountdownsParams struct {
+	SendAfter pgtype.Time `db:"send_after" json:"send_after"`
+	Days      []int32     `db:"days" json:"days"`
+	RowLimit  int32       `db:"row_limit" json:"row_limit"`
+}
+
+type ClaimTripCountdownsRow struct {
+	ID     uuid.UUID   `db:"id" json:"id"`
+	SentOn pgtype.Date `db:"sent_on" json:"sent_on"`
+	Days   int32       `db:"days" json:"days"`
+}
+
+func (q *Queries) ClaimTripCountdowns(ctx context.Context, arg ClaimTripCountdownsParams) ([]ClaimTripCountdownsRow, error) {
+	rows, err := q.db.Query(ctx, claimTripCountdowns, arg.SendAfter, arg.Days, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Link
+	var items []ClaimTripCountdownsRow
 	for rows.Next() {
-		var i Link
-		if err := rows.Scan(
-			&i.ID,
-			&i.TripID,
-			&i.Title,
-			&i.Url,
-		); err != nil {
+		var i ClaimTripCountdownsRow
+		if err := rows.Scan(&i.ID, &i.SentOn, &i.Days); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -228,89 +347,2056 @@ func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, e
 	return items, nil
 }
 
-const insertTrip = `-- name: InsertTrip :one
-insert into
-  trips (
-    "destination",
-    "owner_email",
-    "owner_name",
-    "starts_at",
-    "ends_at"
-  ) values ($1, $2, $3, $4, $5)
+const confirmTrip = `-- name: ConfirmTrip :execrows
+update trips
+set
+    "is_confirmed" = true
+where
+    id = $1
+    and is_confirmed = false
+`
+
+func (q *Queries) ConfirmTrip(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, confirmTrip, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const consumeConfirmationToken = `-- name: ConsumeConfirmationToken :one
+update confirmation_tokens
+set
+    "used_at" = now()
+where
+    id = $1
+    and purpose = $2
+    and used_at is null
+    and expires_at > now()
+returning "subject_id"
+`
+
+type ConsumeConfirmationTokenParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Purpose string    `db:"purpose" json:"purpose"`
+}
+
+func (q *Queries) ConsumeConfirmationToken(ctx context.Context, arg ConsumeConfirmationTokenParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, consumeConfirmationToken, arg.ID, arg.Purpose)
+	var subject_id uuid.UUID
+	err := row.Scan(&subject_id)
+	return subject_id, err
+}
+
+const createAccountClaim = `-- name: CreateAccountClaim :exec
+insert into account_claims
+    ( "token_id", "name", "password_hash", "locale" ) values
+    ( $1, $2, $3, $4 )
+`
+
+type CreateAccountClaimParams struct {
+	TokenID      uuid.UUID `db:"token_id" json:"token_id"`
+	Name         string    `db:"name" json:"name"`
+	PasswordHash string    `db:"password_hash" json:"password_hash"`
+	Locale       string    `db:"locale" json:"locale"`
+}
+
+func (q *Queries) CreateAccountClaim(ctx context.Context, arg CreateAccountClaimParams) error {
+	_, err := q.db.Exec(ctx, createAccountClaim,
+		arg.TokenID,
+		arg.Name,
+		arg.PasswordHash,
+		arg.Locale,
+	)
+	return err
+}
+
+const createActivity = `-- name: CreateActivity :one
+insert into activities
+    ( "trip_id", "title", "occurs_at", "time_zone", "ends_at", "location", "latitude", "longitude", "category", "notes" ) values
+    ( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10 )
 returning "id"
 `
 
-type InsertTripParams struct {
-	Destination string           `db:"destination" json:"destination"`
-	OwnerEmail  string           `db:"owner_email" json:"owner_email"`
-	OwnerName   string           `db:"owner_name" json:"owner_name"`
-	StartsAt    pgtype.Timestamp `db:"starts_at" json:"starts_at"`
-	EndsAt      pgtype.Timestamp `db:"ends_at" json:"ends_at"`
+type CreateActivityParams struct {
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+	Title     string             `db:"title" json:"title"`
+	OccursAt  pgtype.Timestamptz `db:"occurs_at" json:"occurs_at"`
+	TimeZone  pgtype.Text        `db:"time_zone" json:"time_zone"`
+	EndsAt    pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	Location  pgtype.Text        `db:"location" json:"location"`
+	Latitude  pgtype.Float8      `db:"latitude" json:"latitude"`
+	Longitude pgtype.Float8      `db:"longitude" json:"longitude"`
+	Category  string             `db:"category" json:"category"`
+	Notes     string             `db:"notes" json:"notes"`
 }
 
-func (q *Queries) InsertTrip(ctx context.Context, arg InsertTripParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, insertTrip,
-		arg.Destination,
-		arg.OwnerEmail,
-		arg.OwnerName,
-		arg.StartsAt,
+func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createActivity,
+		arg.TripID,
+		arg.Title,
+		arg.OccursAt,
+		arg.TimeZone,
 		arg.EndsAt,
+		arg.Location,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Category,
+		arg.Notes,
 	)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
-const inviteParticipantToTrip = `-- name: InviteParticipantToTrip :one
-INSERT INTO participants
-    ( "trip_id", "email" ) VALUES
-    ( $1, $2 )
-RETURNING "id"
+const createConfirmationToken = `-- name: CreateConfirmationToken :one
+insert into confirmation_tokens
+    ( "purpose", "subject_id", "expires_at" ) values
+    ( $1, $2, $3 )
+returning "id"
 `
 
-type InviteParticipantToTripParams struct {
-	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
-	Email  string    `db:"email" json:"email"`
+type CreateConfirmationTokenParams struct {
+	Purpose   string             `db:"purpose" json:"purpose"`
+	SubjectID uuid.UUID          `db:"subject_id" json:"subject_id"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
 }
 
-func (q *Queries) InviteParticipantToTrip(ctx context.Context, arg InviteParticipantToTripParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, inviteParticipantToTrip, arg.TripID, arg.Email)
+func (q *Queries) CreateConfirmationToken(ctx context.Context, arg CreateConfirmationTokenParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createConfirmationToken, arg.Purpose, arg.SubjectID, arg.ExpiresAt)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
-type InviteParticipantsToTripParams struct {
+const createSession = `-- name: CreateSession :exec
+insert into sessions
+    ( "user_id", "token_hash", "expires_at" ) values
+    ( $1, $2, $3 )
+`
+
+type CreateSessionParams struct {
+	UserID    uuid.UUID          `db:"user_id" json:"user_id"`
+	TokenHash []byte             `db:"token_hash" json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) error {
+	_, err := q.db.Exec(ctx, createSession, arg.UserID, arg.TokenHash, arg.ExpiresAt)
+	return err
+}
+
+const createTripLink = `-- name: CreateTripLink :one
+insert into links
+    ( "trip_id", "title", "url" ) values
+    ( $1, $2, $3 )
+returning "id"
+`
+
+type CreateTripLinkParams struct {
 	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
-	Email  string    `db:"email" json:"email"`
+	Title  string    `db:"title" json:"title"`
+	Url    string    `db:"url" json:"url"`
 }
 
-const updateTrip = `-- name: UpdateTrip :exec
-UPDATE trips
-SET 
-    "destination" = $1,
-    "ends_at" = $2,
-    "starts_at" = $3,
-    "is_confirmed" = $4
-where
-    id = $5
+func (q *Queries) CreateTripLink(ctx context.Context, arg CreateTripLinkParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createTripLink, arg.TripID, arg.Title, arg.Url)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createUser = `-- name: CreateUser :one
+insert into users
+    ( "email", "name", "password_hash", "locale" ) values
+    ( $1, $2, $3, $4 )
+on conflict ("email") do nothing
+returning "id"
 `
 
-type UpdateTripParams struct {
-	Destination string           `db:"destination" json:"destination"`
-	EndsAt      pgtype.Timestamp `db:"ends_at" json:"ends_at"`
-	StartsAt    pgtype.Timestamp `db:"starts_at" json:"starts_at"`
-	IsConfirmed bool             `db:"is_confirmed" json:"is_confirmed"`
-	ID          uuid.UUID        `db:"id" json:"id"`
+type CreateUserParams struct {
+	Email        string `db:"email" json:"email"`
+	Name         string `db:"name" json:"name"`
+	PasswordHash string `db:"password_hash" json:"password_hash"`
+	Locale       string `db:"locale" json:"locale"`
 }
 
-func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) error {
-	_, err := q.db.Exec(ctx, updateTrip,
-		arg.Destination,
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createUser,
+		arg.Email,
+		arg.Name,
+		arg.PasswordHash,
+		arg.Locale,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteActivity = `-- name: DeleteActivity :one
+delete from activities
+where
+    id = $1
+    and trip_id = $2
+returning "id", "trip_id", "title", "occurs_at", "time_zone", "ends_at", "location", "latitude", "longitude", "category", "notes"
+`
+
+type DeleteActivityParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) DeleteActivity(ctx context.Context, arg DeleteActivityParams) (Activity, error) {
+	row := q.db.QueryRow(ctx, deleteActivity, arg.ID, arg.TripID)
+	var i Activity
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Title,
+		&i.OccursAt,
+		&i.TimeZone,
+		&i.EndsAt,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Category,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const deleteBudgetCategories = `-- name: DeleteBudgetCategories :exec
+delete from budget_categories
+where
+    trip_id = $1
+`
+
+func (q *Queries) DeleteBudgetCategories(ctx context.Context, tripID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteBudgetCategories, tripID)
+	return err
+}
+
+const deleteCalendarSubscription = `-- name: DeleteCalendarSubscription :execrows
+delete from calendar_subscriptions
+where
+    trip_id = $1
+    and user_id = $2
+`
+
+type DeleteCalendarSubscriptionParams struct {
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) DeleteCalendarSubscription(ctx context.Context, arg DeleteCalendarSubscriptionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCalendarSubscription, arg.TripID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpense = `-- name: DeleteExpense :execrows
+delete from expenses
+where
+    id = $1
+    and trip_id = $2
+`
+
+type DeleteExpenseParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) DeleteExpense(ctx context.Context, arg DeleteExpenseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpense, arg.ID, arg.TripID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSession = `-- name: DeleteSession :exec
+delete from sessions
+where
+    token_hash = $1
+`
+
+func (q *Queries) DeleteSession(ctx context.Context, tokenHash []byte) error {
+	_, err := q.db.Exec(ctx, deleteSession, tokenHash)
+	return err
+}
+
+const deleteTrip = `-- name: DeleteTrip :one
+delete from trips
+where
+    id = $1
+returning "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at", "time_zone"
+`
+
+func (q *Queries) DeleteTrip(ctx context.Context, id uuid.UUID) (Trip, error) {
+	row := q.db.QueryRow(ctx, deleteTrip, id)
+	var i Trip
+	err := row.Scan(
+		&i.ID,
+		&i.Destination,
+		&i.OwnerEmail,
+		&i.OwnerName,
+		&i.IsConfirmed,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const deleteTripLink = `-- name: DeleteTripLink :one
+delete from links
+where
+    id = $1
+    and trip_id = $2
+returning "id", "trip_id", "title", "url", "created_at"
+`
+
+type DeleteTripLinkParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) DeleteTripLink(ctx context.Context, arg DeleteTripLinkParams) (Link, error) {
+	row := q.db.QueryRow(ctx, deleteTripLink, arg.ID, arg.TripID)
+	var i Link
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Title,
+		&i.Url,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const demoteTripOwner = `-- name: DemoteTripOwner :exec
+update participants
+set
+    "role" = 'editor'
+where
+    trip_id = $1
+    and "role" = 'owner'
+`
+
+func (q *Queries) DemoteTripOwner(ctx context.Context, tripID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, demoteTripOwner, tripID)
+	return err
+}
+
+const expireInvitations = `-- name: ExpireInvitations :execrows
+update participants
+set
+    "status" = 'expired'
+from trips
+where
+    trips.id = participants.trip_id
+    and participants."status" = 'invited'
+    and participants."role" <> 'owner'
+    and trips."is_confirmed"
+    and (
+        trips."starts_at" <= now()
+        or (
+            $1::timestamptz is not null
+            and exists (
+                select 1
+                from invitation_reminders
+                where
+                    invitation_reminders.participant_id = participants.id
+                    and invitation_reminders."invited_at" <= $1::timestamptz
+            )
+        )
+    )
+`
+
+func (q *Queries) ExpireInvitations(ctx context.Context, invitedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, expireInvitations, invitedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getBudget = `-- name: GetBudget :one
+select
+    "trip_id",
+    "currency",
+    "total",
+    "updated_at"
+from budgets
+where
+    trip_id = $1
+`
+
+func (q *Queries) GetBudget(ctx context.Context, tripID uuid.UUID) (Budget, error) {
+	row := q.db.QueryRow(ctx, getBudget, tripID)
+	var i Budget
+	err := row.Scan(
+		&i.TripID,
+		&i.Currency,
+		&i.Total,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBudgetCategories = `-- name: GetBudgetCategories :many
+select
+    "trip_id",
+    "category",
+    "amount"
+from budget_categories
+where
+    trip_id = $1
+order by "category"
+`
+
+func (q *Queries) GetBudgetCategories(ctx context.Context, tripID uuid.UUID) ([]BudgetCategory, error) {
+	rows, err := q.db.Query(ctx, getBudgetCategories, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BudgetCategory
+	for rows.Next() {
+		var i BudgetCategory
+		if err := rows.Scan(&i.TripID, &i.Category, &i.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCalendarSubscriptionUser = `-- name: GetCalendarSubscriptionUser :one
+select
+    users."id",
+    users."email",
+    users."name",
+    users."password_hash",
+    users."created_at",
+    users."locale"
+from calendar_subscriptions
+join users on users.id = calendar_subscriptions.user_id
+where
+    calendar_subscriptions.trip_id = $1
+    and calendar_subscriptions.token_hash = $2
+`
+
+type GetCalendarSubscriptionUserParams struct {
+	TripID    uuid.UUID `db:"trip_id" json:"trip_id"`
+	TokenHash []byte    `db:"token_hash" json:"token_hash"`
+}
+
+func (q *Queries) GetCalendarSubscriptionUser(ctx context.Context, arg GetCalendarSubscriptionUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, getCalendarSubscriptionUser, arg.TripID, arg.TokenHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const getExchangeRates = `-- name: GetExchangeRates :many
+select distinct on ("base", "quote")
+    "base",
+    "quote",
+    "valid_on",
+    "rate"
+from exchange_rates
+where
+    ("base" = any($1::text[]) or "quote" = any($1::text[]))
+    and "valid_on" <= $2
+order by "base", "quote", "valid_on" desc
+`
+
+type GetExchangeRatesParams struct {
+	Currencies []string    `db:"currencies" json:"currencies"`
+	ValidOn    pgtype.Date `db:"valid_on" json:"valid_on"`
+}
+
+func (q *Queries) GetExchangeRates(ctx context.Context, arg GetExchangeRatesParams) ([]ExchangeRate, error) {
+	rows, err := q.db.Query(ctx, getExchangeRates, arg.Currencies, arg.ValidOn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExchangeRate
+	for rows.Next() {
+		var i ExchangeRate
+		if err := rows.Scan(
+			&i.Base,
+			&i.Quote,
+			&i.ValidOn,
+			&i.Rate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpenseShares = `-- name: GetExpenseShares :many
+select
+    "expense_id",
+    "participant_id",
+    "shares",
+    "amount"
+from expense_shares
+where
+    expense_id = any($1::uuid[])
+order by "expense_id", "participant_id"
+`
+
+func (q *Queries) GetExpenseShares(ctx context.Context, expenseIds []uuid.UUID) ([]ExpenseShare, error) {
+	rows, err := q.db.Query(ctx, getExpenseShares, expenseIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExpenseShare
+	for rows.Next() {
+		var i ExpenseShare
+		if err := rows.Scan(
+			&i.ExpenseID,
+			&i.ParticipantID,
+			&i.Shares,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipant = `-- name: GetParticipant :one
+select
+    "id", 
+    "trip_id", 
+    "email", 
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
+from participants
+where
+    id = $1
+`
+
+func (q *Queries) GetParticipant(ctx context.Context, id uuid.UUID) (Participant, error) {
+	row := q.db.QueryRow(ctx, getParticipant, id)
+	var i Participant
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Email,
+		&i.Role,
+		&i.CreatedAt,
+		&i.Locale,
+		&i.Name,
+		&i.Status,
+		&i.RespondedAt,
+		&i.RemovedAt,
+	)
+	return i, err
+}
+
+const getParticipants = `-- name: GetParticipants :many
+select
+    "id", 
+    "trip_id", 
+    "email", 
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
+from participants
+where
+    trip_id = $1
+`
+
+func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Participant, error) {
+	rows, err := q.db.Query(ctx, getParticipants, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Participant
+	for rows.Next() {
+		var i Participant
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Email,
+			&i.Role,
+			&i.CreatedAt,
+			&i.Locale,
+			&i.Name,
+			&i.Status,
+			&i.RespondedAt,
+			&i.RemovedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSessionUser = `-- name: GetSessionUser :one
+select
+    users."id",
+    users."email",
+    users."name",
+    users."password_hash",
+    users."created_at",
+    users."locale"
+from sessions
+join users on users.id = sessions.user_id
+where
+    sessions.token_hash = $1
+    and sessions.expires_at > now()
+`
+
+func (q *Queries) GetSessionUser(ctx context.Context, tokenHash []byte) (User, error) {
+	row := q.db.QueryRow(ctx, getSessionUser, tokenHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const getTrip = `-- name: GetTrip :one
+select
+    "id", 
+    "destination", 
+    "owner_email", 
+    "owner_name", 
+    "is_confirmed", 
+    "starts_at", 
+    "ends_at",
+    "time_zone"
+from trips
+where
+    id = $1
+`
+
+func (q *Queries) GetTrip(ctx context.Context, id uuid.UUID) (Trip, error) {
+	row := q.db.QueryRow(ctx, getTrip, id)
+	var i Trip
+	err := row.Scan(
+		&i.ID,
+		&i.Destination,
+		&i.OwnerEmail,
+		&i.OwnerName,
+		&i.IsConfirmed,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const getTripActivities = `-- name: GetTripActivities :many
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "occurs_at",
+    "time_zone",
+    "ends_at",
+    "location",
+    "latitude",
+    "longitude",
+    "category",
+    "notes"
+from activities
+where
+    trip_id = $1
+order by "occurs_at", "id"
+`
+
+func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Activity, error) {
+	rows, err := q.db.Query(ctx, getTripActivities, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Activity
+	for rows.Next() {
+		var i Activity
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Title,
+			&i.OccursAt,
+			&i.TimeZone,
+			&i.EndsAt,
+			&i.Location,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Category,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripActivity = `-- name: GetTripActivity :one
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "occurs_at",
+    "time_zone",
+    "ends_at",
+    "location",
+    "latitude",
+    "longitude",
+    "category",
+    "notes"
+from activities
+where
+    id = $1
+    and trip_id = $2
+`
+
+type GetTripActivityParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) GetTripActivity(ctx context.Context, arg GetTripActivityParams) (Activity, error) {
+	row := q.db.QueryRow(ctx, getTripActivity, arg.ID, arg.TripID)
+	var i Activity
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Title,
+		&i.OccursAt,
+		&i.TimeZone,
+		&i.EndsAt,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Category,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const getTripLinks = `-- name: GetTripLinks :many
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "url",
+    "created_at"
+from links
+where
+    trip_id = $1
+`
+
+func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, error) {
+	rows, err := q.db.Query(ctx, getTripLinks, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Link
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Title,
+			&i.Url,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripOwedTotals = `-- name: GetTripOwedTotals :many
+select
+    expense_shares."participant_id",
+    expenses."currency",
+    sum(expense_shares."amount")::numeric as "total"
+from expense_shares
+join expenses on expenses.id = expense_shares.expense_id
+where
+    expenses.trip_id = $1
+group by expense_shares."participant_id", expenses."currency"
+`
+
+type GetTripOwedTotalsRow struct {
+	ParticipantID uuid.UUID      `db:"participant_id" json:"participant_id"`
+	Currency      string         `db:"currency" json:"currency"`
+	Total         pgtype.Numeric `db:"total" json:"total"`
+}
+
+func (q *Queries) GetTripOwedTotals(ctx context.Context, tripID uuid.UUID) ([]GetTripOwedTotalsRow, error) {
+	rows, err := q.db.Query(ctx, getTripOwedTotals, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTripOwedTotalsRow
+	for rows.Next() {
+		var i GetTripOwedTotalsRow
+		if err := rows.Scan(&i.ParticipantID, &i.Currency, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripPaidTotals = `-- name: GetTripPaidTotals :many
+select
+    "payer_id" as "participant_id",
+    "currency",
+    sum("amount")::numeric as "total"
+from expenses
+where
+    trip_id = $1
+group by "payer_id", "currency"
+`
+
+type GetTripPaidTotalsRow struct {
+	ParticipantID uuid.UUID      `db:"participant_id" json:"participant_id"`
+	Currency      string         `db:"currency" json:"currency"`
+	Total         pgtype.Numeric `db:"total" json:"total"`
+}
+
+func (q *Queries) GetTripPaidTotals(ctx context.Context, tripID uuid.UUID) ([]GetTripPaidTotalsRow, error) {
+	rows, err := q.db.Query(ctx, getTripPaidTotals, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTripPaidTotalsRow
+	for rows.Next() {
+		var i GetTripPaidTotalsRow
+		if err := rows.Scan(&i.ParticipantID, &i.Currency, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripParticipantByEmail = `-- name: GetTripParticipantByEmail :one
+select
+    "id", 
+    "trip_id", 
+    "email", 
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
+from participants
+where
+    trip_id = $1
+    and lower(email) = lower($2)
+`
+
+type GetTripParticipantByEmailParams struct {
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+	Email  string    `db:"email" json:"email"`
+}
+
+func (q *Queries) GetTripParticipantByEmail(ctx context.Context, arg GetTripParticipantByEmailParams) (Participant, error) {
+	row := q.db.QueryRow(ctx, getTripParticipantByEmail, arg.TripID, arg.Email)
+	var i Participant
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Email,
+		&i.Role,
+		&i.CreatedAt,
+		&i.Locale,
+		&i.Name,
+		&i.Status,
+		&i.RespondedAt,
+		&i.RemovedAt,
+	)
+	return i, err
+}
+
+const getTripSpending = `-- name: GetTripSpending :many
+select
+    coalesce(activities."category", 'other')::varchar as "category",
+    expenses."currency",
+    (expenses."created_at" at time zone trips."time_zone")::date as "spent_on",
+    sum(expenses."amount")::numeric as "total"
+from expenses
+join trips on trips.id = expenses.trip_id
+left join activities on activities.id = expenses.activity_id
+where
+    expenses.trip_id = $1
+group by 1, 2, 3
+order by 1, 2, 3
+`
+
+type GetTripSpendingRow struct {
+	Category string         `db:"category" json:"category"`
+	Currency string         `db:"currency" json:"currency"`
+	SpentOn  pgtype.Date    `db:"spent_on" json:"spent_on"`
+	Total    pgtype.Numeric `db:"total" json:"total"`
+}
+
+func (q *Queries) GetTripSpending(ctx context.Context, tripID uuid.UUID) ([]GetTripSpendingRow, error) {
+	rows, err := q.db.Query(ctx, getTripSpending, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTripSpendingRow
+	for rows.Next() {
+		var i GetTripSpendingRow
+		if err := rows.Scan(
+			&i.Category,
+			&i.Currency,
+			&i.SpentOn,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUser = `-- name: GetUser :one
+select
+    "id",
+    "email",
+    "name",
+    "password_hash",
+    "created_at",
+    "locale"
+from users
+where
+    id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+select
+    "id",
+    "email",
+    "name",
+    "password_hash",
+    "created_at",
+    "locale"
+from users
+where
+    email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.Locale,
+	)
+	return i, err
+}
+
+type InsertBudgetCategoriesParams struct {
+	TripID   uuid.UUID      `db:"trip_id" json:"trip_id"`
+	Category string         `db:"category" json:"category"`
+	Amount   pgtype.Numeric `db:"amount" json:"amount"`
+}
+
+const insertExpense = `-- name: InsertExpense :one
+insert into expenses
+    ( "trip_id", "payer_id", "activity_id", "description", "amount", "currency", "split" ) values
+    ( $1, $2, $3, $4, $5, $6, $7 )
+returning "id"
+`
+
+type InsertExpenseParams struct {
+	TripID      uuid.UUID      `db:"trip_id" json:"trip_id"`
+	PayerID     uuid.UUID      `db:"payer_id" json:"payer_id"`
+	ActivityID  pgtype.UUID    `db:"activity_id" json:"activity_id"`
+	Description string         `db:"description" json:"description"`
+	Amount      pgtype.Numeric `db:"amount" json:"amount"`
+	Currency    string         `db:"currency" json:"currency"`
+	Split       string         `db:"split" json:"split"`
+}
+
+func (q *Queries) InsertExpense(ctx context.Context, arg InsertExpenseParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertExpense,
+		arg.TripID,
+		arg.PayerID,
+		arg.ActivityID,
+		arg.Description,
+		arg.Amount,
+		arg.Currency,
+		arg.Split,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+type InsertExpenseSharesParams struct {
+	ExpenseID     uuid.UUID      `db:"expense_id" json:"expense_id"`
+	ParticipantID uuid.UUID      `db:"participant_id" json:"participant_id"`
+	Shares        pgtype.Int4    `db:"shares" json:"shares"`
+	Amount        pgtype.Numeric `db:"amount" json:"amount"`
+}
+
+const insertOutboxMessage = `-- name: InsertOutboxMessage :exec
+insert into outbox
+    ( "kind", "payload" ) values
+    ( $1, $2 )
+`
+
+type InsertOutboxMessageParams struct {
+	Kind    string `db:"kind" json:"kind"`
+	Payload []byte `db:"payload" json:"payload"`
+}
+
+func (q *Queries) InsertOutboxMessage(ctx context.Context, arg InsertOutboxMessageParams) error {
+	_, err := q.db.Exec(ctx, insertOutboxMessage, arg.Kind, arg.Payload)
+	return err
+}
+
+const insertTrip = `-- name: InsertTrip :one
+insert into
+  trips (
+    "destination",
+    "owner_email",
+    "owner_name",
+    "starts_at",
+    "ends_at",
+    "time_zone"
+  ) values ($1, $2, $3, $4, $5, $6)
+returning "id"
+`
+
+type InsertTripParams struct {
+	Destination string             `db:"destination" json:"destination"`
+	OwnerEmail  string             `db:"owner_email" json:"owner_email"`
+	OwnerName   string             `db:"owner_name" json:"owner_name"`
+	StartsAt    pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt      pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	TimeZone    string             `db:"time_zone" json:"time_zone"`
+}
+
+func (q *Queries) InsertTrip(ctx context.Context, arg InsertTripParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertTrip,
+		arg.Destination,
+		arg.OwnerEmail,
+		arg.OwnerName,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.TimeZone,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const inviteParticipantToTrip = `-- name: InviteParticipantToTrip :one
+INSERT INTO participants
+    ( "trip_id", "email", "locale", "name" ) VALUES
+    ( $1, $2, $3, $4 )
+RETURNING "id"
+`
+
+type InviteParticipantToTripParams struct {
+	TripID uuid.UUID   `db:"trip_id" json:"trip_id"`
+	Email  string      `db:"email" json:"email"`
+	Locale string      `db:"locale" json:"locale"`
+	Name   pgtype.Text `db:"name" json:"name"`
+}
+
+func (q *Queries) InviteParticipantToTrip(ctx context.Context, arg InviteParticipantToTripParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, inviteParticipantToTrip,
+		arg.TripID,
+		arg.Email,
+		arg.Locale,
+		arg.Name,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+type InviteParticipantsToTripParams struct {
+	TripID uuid.UUID   `db:"trip_id" json:"trip_id"`
+	Email  string      `db:"email" json:"email"`
+	Locale string      `db:"locale" json:"locale"`
+	Name   pgtype.Text `db:"name" json:"name"`
+}
+
+const listDigestOptOuts = `-- name: ListDigestOptOuts :many
+select
+    digest_opt_outs."participant_id"
+from digest_opt_outs
+join participants on participants.id = digest_opt_outs.participant_id
+where
+    participants.trip_id = $1
+`
+
+func (q *Queries) ListDigestOptOuts(ctx context.Context, tripID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listDigestOptOuts, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var participant_id uuid.UUID
+		if err := rows.Scan(&participant_id); err != nil {
+			return nil, err
+		}
+		items = append(items, participant_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTripActivities = `-- name: ListTripActivities :many
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "occurs_at",
+    "time_zone",
+    "ends_at",
+    "location",
+    "latitude",
+    "longitude",
+    "category",
+    "notes"
+from activities
+where
+    trip_id = $1
+    and (
+        $2::date is null
+        or ("occurs_at" at time zone coalesce("time_zone", $3::text))::date >= $2::date
+    )
+    and (
+        $4::date is null
+        or ("occurs_at" at time zone coalesce("time_zone", $3::text))::date <= $4::date
+    )
+    and (
+        $5::timestamptz is null
+        or coalesce("ends_at", "occurs_at") >= $5::timestamptz
+    )
+    and (
+        $6::timestamptz is null
+        or ("occurs_at", "id") > ($6::timestamptz, $7::uuid)
+    )
+order by "occurs_at", "id"
+limit $8
+`
+
+type ListTripActivitiesParams struct {
+	TripID        uuid.UUID          `db:"trip_id" json:"trip_id"`
+	FromDay       pgtype.Date        `db:"from_day" json:"from_day"`
+	TripTimeZone  string             `db:"trip_time_zone" json:"trip_time_zone"`
+	ToDay         pgtype.Date        `db:"to_day" json:"to_day"`
+	EndingAfter   pgtype.Timestamptz `db:"ending_after" json:"ending_after"`
+	AfterOccursAt pgtype.Timestamptz `db:"after_occurs_at" json:"after_occurs_at"`
+	AfterID       pgtype.UUID        `db:"after_id" json:"after_id"`
+	RowLimit      int32              `db:"row_limit" json:"row_limit"`
+}
+
+func (q *Queries) ListTripActivities(ctx context.Context, arg ListTripActivitiesParams) ([]Activity, error) {
+	rows, err := q.db.Query(ctx, listTripActivities,
+		arg.TripID,
+		arg.FromDay,
+		arg.TripTimeZone,
+		arg.ToDay,
+		arg.EndingAfter,
+		arg.AfterOccursAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Activity
+	for rows.Next() {
+		var i Activity
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Title,
+			&i.OccursAt,
+			&i.TimeZone,
+			&i.EndsAt,
+			&i.Location,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Category,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTripExpenses = `-- name: ListTripExpenses :many
+select
+    "id",
+    "trip_id",
+    "payer_id",
+    "activity_id",
+    "description",
+    "amount",
+    "currency",
+    "split",
+    "created_at"
+from expenses
+where
+    trip_id = $1
+    and (
+        $2::timestamptz is null
+        or ("created_at", "id") > ($2::timestamptz, $3::uuid)
+    )
+order by "created_at", "id"
+limit $4
+`
+
+type ListTripExpensesParams struct {
+	TripID         uuid.UUID          `db:"trip_id" json:"trip_id"`
+	AfterCreatedAt pgtype.Timestamptz `db:"after_created_at" json:"after_created_at"`
+	AfterID        pgtype.UUID        `db:"after_id" json:"after_id"`
+	RowLimit       int32              `db:"row_limit" json:"row_limit"`
+}
+
+func (q *Queries) ListTripExpenses(ctx context.Context, arg ListTripExpensesParams) ([]Expense, error) {
+	rows, err := q.db.Query(ctx, listTripExpenses,
+		arg.TripID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Expense
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.PayerID,
+			&i.ActivityID,
+			&i.Description,
+			&i.Amount,
+			&i.Currency,
+			&i.Split,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTripLinks = `-- name: ListTripLinks :many
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "url",
+    "created_at"
+from links
+where
+    trip_id = $1
+    and (
+        $2::timestamptz is null
+        or ("created_at", "id") > ($2::timestamptz, $3::uuid)
+    )
+order by "created_at", "id"
+limit $4
+`
+
+type ListTripLinksParams struct {
+	TripID         uuid.UUID          `db:"trip_id" json:"trip_id"`
+	AfterCreatedAt pgtype.Timestamptz `db:"after_created_at" json:"after_created_at"`
+	AfterID        pgtype.UUID        `db:"after_id" json:"after_id"`
+	RowLimit       int32              `db:"row_limit" json:"row_limit"`
+}
+
+func (q *Queries) ListTripLinks(ctx context.Context, arg ListTripLinksParams) ([]Link, error) {
+	rows, err := q.db.Query(ctx, listTripLinks,
+		arg.TripID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Link
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Title,
+			&i.Url,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTripParticipants = `-- name: ListTripParticipants :many
+select
+    "id", 
+    "trip_id", 
+    "email", 
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
+from participants
+where
+    trip_id = $1
+    and (
+        $2::timestamptz is null
+        or ("created_at", "id") > ($2::timestamptz, $3::uuid)
+    )
+order by "created_at", "id"
+limit $4
+`
+
+type ListTripParticipantsParams struct {
+	TripID         uuid.UUID          `db:"trip_id" json:"trip_id"`
+	AfterCreatedAt pgtype.Timestamptz `db:"after_created_at" json:"after_created_at"`
+	AfterID        pgtype.UUID        `db:"after_id" json:"after_id"`
+	RowLimit       int32              `db:"row_limit" json:"row_limit"`
+}
+
+func (q *Queries) ListTripParticipants(ctx context.Context, arg ListTripParticipantsParams) ([]Participant, error) {
+	rows, err := q.db.Query(ctx, listTripParticipants,
+		arg.TripID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Participant
+	for rows.Next() {
+		var i Participant
+		if err := rows.Scan(
+			&i.ID,
+			&i.TripID,
+			&i.Email,
+			&i.Role,
+			&i.CreatedAt,
+			&i.Locale,
+			&i.Name,
+			&i.Status,
+			&i.RespondedAt,
+			&i.RemovedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTripsByEmail = `-- name: ListTripsByEmail :many
+select
+    "id", 
+    "destination", 
+    "owner_email", 
+    "owner_name", 
+    "is_confirmed", 
+    "starts_at", 
+    "ends_at",
+    "time_zone"
+from trips
+where
+    (
+        lower(trips.owner_email) = lower($1)
+        or exists (
+            select 1
+            from participants
+            where
+                participants.trip_id = trips.id
+                and lower(participants.email) = lower($1)
+                and participants.status <> 'removed'
+        )
+    )
+    and ($2::text is null or trips.destination ilike '%' || $2::text || '%')
+    and ($3::date is null or (trips.ends_at at time zone trips.time_zone)::date >= $3::date)
+    and ($4::date is null or (trips.starts_at at time zone trips.time_zone)::date <= $4::date)
+    and ($5::boolean is null or trips.is_confirmed = $5::boolean)
+    and (
+        $6::timestamptz is null
+        or (trips.starts_at, trips.id) > ($6::timestamptz, $7::uuid)
+    )
+order by trips.starts_at, trips.id
+limit $8
+`
+
+type ListTripsByEmailParams struct {
+	Email            string             `db:"email" json:"email"`
+	Destination      pgtype.Text        `db:"destination" json:"destination"`
+	EndsOnOrAfter    pgtype.Date        `db:"ends_on_or_after" json:"ends_on_or_after"`
+	StartsOnOrBefore pgtype.Date        `db:"starts_on_or_before" json:"starts_on_or_before"`
+	IsConfirmed      pgtype.Bool        `db:"is_confirmed" json:"is_confirmed"`
+	AfterStartsAt    pgtype.Timestamptz `db:"after_starts_at" json:"after_starts_at"`
+	AfterID          pgtype.UUID        `db:"after_id" json:"after_id"`
+	RowLimit         int32              `db:"row_limit" json:"row_limit"`
+}
+
+func (q *Queries) ListTripsByEmail(ctx context.Context, arg ListTripsByEmailParams) ([]Trip, error) {
+	rows, err := q.db.Query(ctx, listTripsByEmail,
+		arg.Email,
+		arg.Destination,
+		arg.EndsOnOrAfter,
+		arg.StartsOnOrBefore,
+		arg.IsConfirmed,
+		arg.AfterStartsAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Trip
+	for rows.Next() {
+		var i Trip
+		if err := rows.Scan(
+			&i.ID,
+			&i.Destination,
+			&i.OwnerEmail,
+			&i.OwnerName,
+			&i.IsConfirmed,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTrip = `-- name: LockTrip :one
+select
+    "id", 
+    "destination", 
+    "owner_email", 
+    "owner_name", 
+    "is_confirmed", 
+    "starts_at", 
+    "ends_at",
+    "time_zone"
+from trips
+where
+    id = $1
+for update
+`
+
+func (q *Queries) LockTrip(ctx context.Context, id uuid.UUID) (Trip, error) {
+	row := q.db.QueryRow(ctx, lockTrip, id)
+	var i Trip
+	err := row.Scan(
+		&i.ID,
+		&i.Destination,
+		&i.OwnerEmail,
+		&i.OwnerName,
+		&i.IsConfirmed,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const markInvitationReminded = `-- name: MarkInvitationReminded :exec
+update invitation_reminders
+set
+    "reminders_sent" = "reminders_sent" + 1,
+    "reminded_at" = now()
+where
+    participant_id = $1
+`
+
+func (q *Queries) MarkInvitationReminded(ctx context.Context, participantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markInvitationReminded, participantID)
+	return err
+}
+
+const markOutboxMessageDead = `-- name: MarkOutboxMessageDead :exec
+update outbox
+set
+    "status" = 'dead',
+    "attempts" = "attempts" + 1,
+    "last_error" = $2
+where
+    id = $1
+    and status = 'pending'
+`
+
+type MarkOutboxMessageDeadParams struct {
+	ID        uuid.UUID   `db:"id" json:"id"`
+	LastError pgtype.Text `db:"last_error" json:"last_error"`
+}
+
+func (q *Queries) MarkOutboxMessageDead(ctx context.Context, arg MarkOutboxMessageDeadParams) error {
+	_, err := q.db.Exec(ctx, markOutboxMessageDead, arg.ID, arg.LastError)
+	return err
+}
+
+const markOutboxMessageSent = `-- name: MarkOutboxMessageSent :exec
+update outbox
+set
+    "status" = 'sent',
+    "attempts" = "attempts" + 1,
+    "last_error" = null,
+    "sent_at" = now()
+where
+    id = $1
+    and status = 'pending'
+`
+
+func (q *Queries) MarkOutboxMessageSent(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markOutboxMessageSent, id)
+	return err
+}
+
+const markPendingSummarySent = `-- name: MarkPendingSummarySent :exec
+insert into pending_summaries
+    ( "trip_id", "starts_at", "sent_at" ) values
+    ( $1, $2, now() )
+on conflict ("trip_id") do update
+set
+    "starts_at" = excluded."starts_at",
+    "sent_at" = excluded."sent_at"
+`
+
+type MarkPendingSummarySentParams struct {
+	TripID   uuid.UUID          `db:"trip_id" json:"trip_id"`
+	StartsAt pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+}
+
+func (q *Queries) MarkPendingSummarySent(ctx context.Context, arg MarkPendingSummarySentParams) error {
+	_, err := q.db.Exec(ctx, markPendingSummarySent, arg.TripID, arg.StartsAt)
+	return err
+}
+
+const markTripDigestSent = `-- name: MarkTripDigestSent :exec
+insert into trip_digests
+    ( "trip_id", "kind", "sent_on", "sent_at" ) values
+    ( $1, $2, $3, now() )
+on conflict ("trip_id", "kind", "sent_on") do nothing
+`
+
+type MarkTripDigestSentParams struct {
+	TripID uuid.UUID   `db:"trip_id" json:"trip_id"`
+	Kind   string      `db:"kind" json:"kind"`
+	SentOn pgtype.Date `db:"sent_on" json:"sent_on"`
+}
+
+func (q *Queries) MarkTripDigestSent(ctx context.Context, arg MarkTripDigestSentParams) error {
+	_, err := q.db.Exec(ctx, markTripDigestSent, arg.TripID, arg.Kind, arg.SentOn)
+	return err
+}
+
+const optInToDigests = `-- name: OptInToDigests :exec
+delete from digest_opt_outs
+where
+    participant_id = $1
+`
+
+func (q *Queries) OptInToDigests(ctx context.Context, participantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, optInToDigests, participantID)
+	return err
+}
+
+const optOutOfDigests = `-- name: OptOutOfDigests :exec
+insert into digest_opt_outs
+    ( "participant_id" ) values
+    ( $1 )
+on conflict ("participant_id") do nothing
+`
+
+func (q *Queries) OptOutOfDigests(ctx context.Context, participantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, optOutOfDigests, participantID)
+	return err
+}
+
+const promoteTripOwner = `-- name: PromoteTripOwner :exec
+update participants
+set
+    "role" = 'owner'
+where
+    id = $1
+`
+
+func (q *Queries) PromoteTripOwner(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, promoteTripOwner, id)
+	return err
+}
+
+const reinviteParticipant = `-- name: ReinviteParticipant :execrows
+update participants
+set
+    "status" = 'invited',
+    "name" = coalesce($1, "name"),
+    "locale" = $2,
+    "responded_at" = null,
+    "removed_at" = null
+where
+    id = $3
+    and "status" in ('removed', 'expired')
+`
+
+type ReinviteParticipantParams struct {
+	Name   pgtype.Text `db:"name" json:"name"`
+	Locale string      `db:"locale" json:"locale"`
+	ID     uuid.UUID   `db:"id" json:"id"`
+}
+
+func (q *Queries) ReinviteParticipant(ctx context.Context, arg ReinviteParticipantParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reinviteParticipant, arg.Name, arg.Locale, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeParticipant = `-- name: RemoveParticipant :one
+update participants
+set
+    "status" = 'removed',
+    "removed_at" = now()
+where
+    id = $1
+    and trip_id = $2
+    and "status" <> 'removed'
+returning "id", "trip_id", "email", "role", "created_at", "locale", "name", "status", "responded_at", "removed_at"
+`
+
+type RemoveParticipantParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) RemoveParticipant(ctx context.Context, arg RemoveParticipantParams) (Participant, error) {
+	row := q.db.QueryRow(ctx, removeParticipant, arg.ID, arg.TripID)
+	var i Participant
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Email,
+		&i.Role,
+		&i.CreatedAt,
+		&i.Locale,
+		&i.Name,
+		&i.Status,
+		&i.RespondedAt,
+		&i.RemovedAt,
+	)
+	return i, err
+}
+
+const resetInvitationReminders = `-- name: ResetInvitationReminders :exec
+insert into invitation_reminders
+    ( "participant_id", "invited_at", "reminders_sent", "reminded_at" ) values
+    ( $1, now(), 0, null )
+on conflict ("participant_id") do update
+set
+    "invited_at" = now(),
+    "reminders_sent" = 0,
+    "reminded_at" = null
+`
+
+func (q *Queries) ResetInvitationReminders(ctx context.Context, participantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, resetInvitationReminders, participantID)
+	return err
+}
+
+const retryOutboxMessage = `-- name: RetryOutboxMessage :exec
+update outbox
+set
+    "attempts" = "attempts" + 1,
+    "last_error" = $2,
+    "next_attempt_at" = $3
+where
+    id = $1
+    and status = 'pending'
+`
+
+type RetryOutboxMessageParams struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+}
+
+func (q *Queries) RetryOutboxMessage(ctx context.Context, arg RetryOutboxMessageParams) error {
+	_, err := q.db.Exec(ctx, retryOutboxMessage, arg.ID, arg.LastError, arg.NextAttemptAt)
+	return err
+}
+
+const setParticipantStatus = `-- name: SetParticipantStatus :execrows
+update participants
+set
+    "status" = $1,
+    "name" = coalesce($2, "name"),
+    "responded_at" = now()
+where
+    id = $3
+    and "status" not in ('removed', 'expired')
+`
+
+type SetParticipantStatusParams struct {
+	Status string      `db:"status" json:"status"`
+	Name   pgtype.Text `db:"name" json:"name"`
+	ID     uuid.UUID   `db:"id" json:"id"`
+}
+
+func (q *Queries) SetParticipantStatus(ctx context.Context, arg SetParticipantStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setParticipantStatus, arg.Status, arg.Name, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateActivity = `-- name: UpdateActivity :execrows
+update activities
+set
+    "title" = $1,
+    "occurs_at" = $2,
+    "time_zone" = $3,
+    "ends_at" = $4,
+    "location" = $5,
+    "latitude" = $6,
+    "longitude" = $7,
+    "category" = $8,
+    "notes" = $9
+where
+    id = $10
+    and trip_id = $11
+`
+
+type UpdateActivityParams struct {
+	Title     string             `db:"title" json:"title"`
+	OccursAt  pgtype.Timestamptz `db:"occurs_at" json:"occurs_at"`
+	TimeZone  pgtype.Text        `db:"time_zone" json:"time_zone"`
+	EndsAt    pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	Location  pgtype.Text        `db:"location" json:"location"`
+	Latitude  pgtype.Float8      `db:"latitude" json:"latitude"`
+	Longitude pgtype.Float8      `db:"longitude" json:"longitude"`
+	Category  string             `db:"category" json:"category"`
+	Notes     string             `db:"notes" json:"notes"`
+	ID        uuid.UUID          `db:"id" json:"id"`
+	TripID    uuid.UUID          `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) UpdateActivity(ctx context.Context, arg UpdateActivityParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateActivity,
+		arg.Title,
+		arg.OccursAt,
+		arg.TimeZone,
+		arg.EndsAt,
+		arg.Location,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Category,
+		arg.Notes,
+		arg.ID,
+		arg.TripID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateParticipantRole = `-- name: UpdateParticipantRole :exec
+update participants
+set
+    "role" = $1
+where
+    id = $2
+`
+
+type UpdateParticipantRoleParams struct {
+	Role string    `db:"role" json:"role"`
+	ID   uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateParticipantRole(ctx context.Context, arg UpdateParticipantRoleParams) error {
+	_, err := q.db.Exec(ctx, updateParticipantRole, arg.Role, arg.ID)
+	return err
+}
+
+const updateTrip = `-- name: UpdateTrip :exec
+UPDATE trips
+SET 
+    "destination" = $1,
+    "ends_at" = $2,
+    "starts_at" = $3,
+    "time_zone" = $4
+where
+    id = $5
+`
+
+type UpdateTripParams struct {
+	Destination string             `db:"destination" json:"destination"`
+	EndsAt      pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	StartsAt    pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	TimeZone    string             `db:"time_zone" json:"time_zone"`
+	ID          uuid.UUID          `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) error {
+	_, err := q.db.Exec(ctx, updateTrip,
+		arg.Destination,
 		arg.EndsAt,
 		arg.StartsAt,
-		arg.IsConfirmed,
+		arg.TimeZone,
+		arg.ID,
+	)
+	return err
+}
+
+const updateTripLink = `-- name: UpdateTripLink :execrows
+update links
+set
+    "title" = $1,
+    "url" = $2
+where
+    id = $3
+    and trip_id = $4
+`
+
+type UpdateTripLinkParams struct {
+	Title  string    `db:"title" json:"title"`
+	Url    string    `db:"url" json:"url"`
+	ID     uuid.UUID `db:"id" json:"id"`
+	TripID uuid.UUID `db:"trip_id" json:"trip_id"`
+}
+
+func (q *Queries) UpdateTripLink(ctx context.Context, arg UpdateTripLinkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateTripLink,
+		arg.Title,
+		arg.Url,
 		arg.ID,
+		arg.TripID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateTripOwner = `-- name: UpdateTripOwner :exec
+update trips
+set
+    "owner_email" = $1,
+    "owner_name" = $2
+where
+    id = $3
+`
+
+type UpdateTripOwnerParams struct {
+	OwnerEmail string    `db:"owner_email" json:"owner_email"`
+	OwnerName  string    `db:"owner_name" json:"owner_name"`
+	ID         uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateTripOwner(ctx context.Context, arg UpdateTripOwnerParams) error {
+	_, err := q.db.Exec(ctx, updateTripOwner, arg.OwnerEmail, arg.OwnerName, arg.ID)
+	return err
+}
+
+const upsertBudget = `-- name: UpsertBudget :exec
+insert into budgets
+    ( "trip_id", "currency", "total" ) values
+    ( $1, $2, $3 )
+on conflict ("trip_id") do update
+set
+    "currency" = excluded."currency",
+    "total" = excluded."total",
+    "updated_at" = now()
+`
+
+type UpsertBudgetParams struct {
+	TripID   uuid.UUID      `db:"trip_id" json:"trip_id"`
+	Currency string         `db:"currency" json:"currency"`
+	Total    pgtype.Numeric `db:"total" json:"total"`
+}
+
+func (q *Queries) UpsertBudget(ctx context.Context, arg UpsertBudgetParams) error {
+	_, err := q.db.Exec(ctx, upsertBudget, arg.TripID, arg.Currency, arg.Total)
+	return err
+}
+
+const upsertCalendarSubscription = `-- name: UpsertCalendarSubscription :exec
+insert into calendar_subscriptions
+    ( "trip_id", "user_id", "token_hash" ) values
+    ( $1, $2, $3 )
+on conflict ("trip_id", "user_id") do update
+set
+    "token_hash" = excluded."token_hash",
+    "created_at" = now()
+`
+
+type UpsertCalendarSubscriptionParams struct {
+	TripID    uuid.UUID `db:"trip_id" json:"trip_id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	TokenHash []byte    `db:"token_hash" json:"token_hash"`
+}
+
+func (q *Queries) UpsertCalendarSubscription(ctx context.Context, arg UpsertCalendarSubscriptionParams) error {
+	_, err := q.db.Exec(ctx, upsertCalendarSubscription, arg.TripID, arg.UserID, arg.TokenHash)
+	return err
+}
+
+const upsertExchangeRate = `-- name: UpsertExchangeRate :exec
+insert into exchange_rates
+    ( "base", "quote", "valid_on", "rate" ) values
+    ( $1, $2, $3, $4 )
+on conflict ("base", "quote", "valid_on") do update
+set
+    "rate" = excluded."rate"
+`
+
+type UpsertExchangeRateParams struct {
+	Base    string         `db:"base" json:"base"`
+	Quote   string         `db:"quote" json:"quote"`
+	ValidOn pgtype.Date    `db:"valid_on" json:"valid_on"`
+	Rate    pgtype.Numeric `db:"rate" json:"rate"`
+}
+
+func (q *Queries) UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) error {
+	_, err := q.db.Exec(ctx, upsertExchangeRate,
+		arg.Base,
+		arg.Quote,
+		arg.ValidOn,
+		arg.Rate,
 	)
 	return err
 }
@@ -5,8 +5,9 @@ insert into
     "owner_email",
     "owner_name",
     "starts_at",
-    "ends_at"
-  ) values ($1, $2, $3, $4, $5)
+    "ends_at",
+    "time_zone"
+  ) values ($1, $2, $3, $4, $5, $6)
 returning "id";
 
 -- name: GetTrip :one
@@ -17,78 +18,293 @@ select
     "owner_name", 
     "is_confirmed", 
     "starts_at", 
-    "ends_at"
+    "ends_at",
+    "time_zone"
 from trips
 where
     id = $1;
 
+-- name: LockTrip :one
+select
+    "id", 
+    "destination", 
+    "owner_email", 
+    "owner_name", 
+    "is_confirmed", 
+    "starts_at", 
+    "ends_at",
+    "time_zone"
+from trips
+where
+    id = $1
+for update;
+
+-- name: ListTripsByEmail :many
+select
+    "id", 
+    "destination", 
+    "owner_email", 
+    "owner_name", 
+    "is_confirmed", 
+    "starts_at", 
+    "ends_at",
+    "time_zone"
+from trips
+where
+    (
+        lower(trips.owner_email) = lower(sqlc.arg(email))
+        or exists (
+            select 1
+            from participants
+            where
+                participants.trip_id = trips.id
+                and lower(participants.email) = lower(sqlc.arg(email))
+                and participants.status <> 'removed'
+        )
+    )
+    and (sqlc.narg(destination)::text is null or trips.destination ilike '%' || sqlc.narg(destination)::text || '%')
+    and (sqlc.narg(ends_on_or_after)::date is null or (trips.ends_at at time zone trips.time_zone)::date >= sqlc.narg(ends_on_or_after)::date)
+    and (sqlc.narg(starts_on_or_before)::date is null or (trips.starts_at at time zone trips.time_zone)::date <= sqlc.narg(starts_on_or_before)::date)
+    and (sqlc.narg(is_confirmed)::boolean is null or trips.is_confirmed = sqlc.narg(is_confirmed)::boolean)
+    and (
+        sqlc.narg(after_starts_at)::timestamptz is null
+        or (trips.starts_at, trips.id) > (sqlc.narg(after_starts_at)::timestamptz, sqlc.narg(after_id)::uuid)
+    )
+order by trips.starts_at, trips.id
+limit sqlc.arg(row_limit);
+
 -- name: UpdateTrip :exec
 UPDATE trips
 SET 
     "destination" = $1,
     "ends_at" = $2,
     "starts_at" = $3,
-    "is_confirmed" = $4
+    "time_zone" = $4
 where
     id = $5;
 
+-- name: ConfirmTrip :execrows
+update trips
+set
+    "is_confirmed" = true
+where
+    id = $1
+    and is_confirmed = false;
+
 -- name: GetParticipant :one
 select
     "id", 
     "trip_id", 
     "email", 
-    "is_confirmed"
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
 from participants
 where
     id = $1;
 
--- name: ConfirmParticipant :exec
+-- name: SetParticipantStatus :execrows
+update participants
+set
+    "status" = sqlc.arg(status),
+    "name" = coalesce(sqlc.narg(name), "name"),
+    "responded_at" = now()
+where
+    id = sqlc.arg(id)
+    and "status" not in ('removed', 'expired');
+
+-- name: ReinviteParticipant :execrows
+update participants
+set
+    "status" = 'invited',
+    "name" = coalesce(sqlc.narg(name), "name"),
+    "locale" = sqlc.arg(locale),
+    "responded_at" = null,
+    "removed_at" = null
+where
+    id = sqlc.arg(id)
+    and "status" in ('removed', 'expired');
+
+
+-- name: GetParticipants :many
 select
     "id", 
     "trip_id", 
     "email", 
-    "is_confirmed"
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
 from participants
 where
-    id = $1;
+    trip_id = $1;
+
+-- name: GetTripParticipantByEmail :one
+select
+    "id", 
+    "trip_id", 
+    "email", 
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
+from participants
+where
+    trip_id = $1
+    and lower(email) = lower(sqlc.arg(email));
 
+-- name: UpdateParticipantRole :exec
+update participants
+set
+    "role" = $1
+where
+    id = $2;
 
--- name: GetParticipants :many
+-- name: ListTripParticipants :many
 select
     "id", 
     "trip_id", 
     "email", 
-    "is_confirmed"
+    "role",
+    "created_at",
+    "locale",
+    "name",
+    "status",
+    "responded_at",
+    "removed_at"
 from participants
 where
-    trip_id = $1;
+    trip_id = sqlc.arg(trip_id)
+    and (
+        sqlc.narg(after_created_at)::timestamptz is null
+        or ("created_at", "id") > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid)
+    )
+order by "created_at", "id"
+limit sqlc.arg(row_limit);
 
 -- name: InviteParticipantToTrip :one
 INSERT INTO participants
-    ( "trip_id", "email" ) VALUES
-    ( $1, $2 )
+    ( "trip_id", "email", "locale", "name" ) VALUES
+    ( $1, $2, $3, $4 )
 RETURNING "id";
 
 -- name: InviteParticipantsToTrip :copyfrom
 insert into participants
-    ( "trip_id", "email" ) values
-    ( $1, $2 );
+    ( "trip_id", "email", "locale", "name" ) values
+    ( $1, $2, $3, $4 );
+
+-- name: AddTripOwner :exec
+insert into participants
+    ( "trip_id", "email", "name", "locale", "role", "status", "responded_at" ) values
+    ( $1, $2, $3, $4, 'owner', 'accepted', now() );
+
+-- name: DemoteTripOwner :exec
+update participants
+set
+    "role" = 'editor'
+where
+    trip_id = $1
+    and "role" = 'owner';
+
+-- name: PromoteTripOwner :exec
+update participants
+set
+    "role" = 'owner'
+where
+    id = $1;
+
+-- name: UpdateTripOwner :exec
+update trips
+set
+    "owner_email" = $1,
+    "owner_name" = $2
+where
+    id = $3;
 
 -- name: CreateActivity :one
 insert into activities
-    ( "trip_id", "title", "occurs_at" ) values
-    ( $1, $2, $3 )
+    ( "trip_id", "title", "occurs_at", "time_zone", "ends_at", "location", "latitude", "longitude", "category", "notes" ) values
+    ( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10 )
 returning "id";
 
+-- name: UpdateActivity :execrows
+update activities
+set
+    "title" = $1,
+    "occurs_at" = $2,
+    "time_zone" = $3,
+    "ends_at" = $4,
+    "location" = $5,
+    "latitude" = $6,
+    "longitude" = $7,
+    "category" = $8,
+    "notes" = $9
+where
+    id = $10
+    and trip_id = $11;
+
 -- name: GetTripActivities :many
 select
     "id", 
     "trip_id", 
     "title", 
-    "occurs_at"
+    "occurs_at",
+    "time_zone",
+    "ends_at",
+    "location",
+    "latitude",
+    "longitude",
+    "category",
+    "notes"
 from activities
 where
-    trip_id = $1;
+    trip_id = $1
+order by "occurs_at", "id";
+
+-- name: ListTripActivities :many
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "occurs_at",
+    "time_zone",
+    "ends_at",
+    "location",
+    "latitude",
+    "longitude",
+    "category",
+    "notes"
+from activities
+where
+    trip_id = sqlc.arg(trip_id)
+    and (
+        sqlc.narg(from_day)::date is null
+        or ("occurs_at" at time zone coalesce("time_zone", sqlc.arg(trip_time_zone)::text))::date >= sqlc.narg(from_day)::date
+    )
+    and (
+        sqlc.narg(to_day)::date is null
+        or ("occurs_at" at time zone coalesce("time_zone", sqlc.arg(trip_time_zone)::text))::date <= sqlc.narg(to_day)::date
+    )
+    and (
+        sqlc.narg(ending_after)::timestamptz is null
+        or coalesce("ends_at", "occurs_at") >= sqlc.narg(ending_after)::timestamptz
+    )
+    and (
+        sqlc.narg(after_occurs_at)::timestamptz is null
+        or ("occurs_at", "id") > (sqlc.narg(after_occurs_at)::timestamptz, sqlc.narg(after_id)::uuid)
+    )
+order by "occurs_at", "id"
+limit sqlc.arg(row_limit);
 
 -- name: CreateTripLink :one
 insert into links
@@ -96,13 +312,580 @@ insert into links
     ( $1, $2, $3 )
 returning "id";
 
+-- name: UpdateTripLink :execrows
+update links
+set
+    "title" = $1,
+    "url" = $2
+where
+    id = $3
+    and trip_id = $4;
+
 -- name: GetTripLinks :many
 select
     "id", 
     "trip_id", 
     "title", 
-    "url"
+    "url",
+    "created_at"
 from links
 where
     trip_id = $1;
 
+-- name: ListTripLinks :many
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "url",
+    "created_at"
+from links
+where
+    trip_id = sqlc.arg(trip_id)
+    and (
+        sqlc.narg(after_created_at)::timestamptz is null
+        or ("created_at", "id") > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid)
+    )
+order by "created_at", "id"
+limit sqlc.arg(row_limit);
+
+-- name: CreateUser :one
+insert into users
+    ( "email", "name", "password_hash", "locale" ) values
+    ( $1, $2, $3, $4 )
+on conflict ("email") do nothing
+returning "id";
+
+-- name: CreateAccountClaim :exec
+insert into account_claims
+    ( "token_id", "name", "password_hash", "locale" ) values
+    ( $1, $2, $3, $4 );
+
+-- name: ClaimAccount :execrows
+update users
+set
+    "name" = account_claims."name",
+    "password_hash" = account_claims."password_hash",
+    "locale" = account_claims."locale"
+from account_claims
+where
+    account_claims.token_id = sqlc.arg(token_id)
+    and users.id = sqlc.arg(user_id)
+    and users."password_hash" = '';
+
+-- name: GetUser :one
+select
+    "id",
+    "email",
+    "name",
+    "password_hash",
+    "created_at",
+    "locale"
+from users
+where
+    id = $1;
+
+-- name: GetUserByEmail :one
+select
+    "id",
+    "email",
+    "name",
+    "password_hash",
+    "created_at",
+    "locale"
+from users
+where
+    email = $1;
+
+-- name: CreateSession :exec
+insert into sessions
+    ( "user_id", "token_hash", "expires_at" ) values
+    ( $1, $2, $3 );
+
+-- name: GetSessionUser :one
+select
+    users."id",
+    users."email",
+    users."name",
+    users."password_hash",
+    users."created_at",
+    users."locale"
+from sessions
+join users on users.id = sessions.user_id
+where
+    sessions.token_hash = $1
+    and sessions.expires_at > now();
+
+-- name: DeleteSession :exec
+delete from sessions
+where
+    token_hash = $1;
+
+-- name: CreateConfirmationToken :one
+insert into confirmation_tokens
+    ( "purpose", "subject_id", "expires_at" ) values
+    ( $1, $2, $3 )
+returning "id";
+
+-- name: ConsumeConfirmationToken :one
+update confirmation_tokens
+set
+    "used_at" = now()
+where
+    id = $1
+    and purpose = $2
+    and used_at is null
+    and expires_at > now()
+returning "subject_id";
+
+-- name: DeleteTrip :one
+delete from trips
+where
+    id = $1
+returning "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at", "time_zone";
+
+-- name: DeleteActivity :one
+delete from activities
+where
+    id = $1
+    and trip_id = $2
+returning "id", "trip_id", "title", "occurs_at", "time_zone", "ends_at", "location", "latitude", "longitude", "category", "notes";
+
+-- name: DeleteTripLink :one
+delete from links
+where
+    id = $1
+    and trip_id = $2
+returning "id", "trip_id", "title", "url", "created_at";
+
+-- name: RemoveParticipant :one
+update participants
+set
+    "status" = 'removed',
+    "removed_at" = now()
+where
+    id = $1
+    and trip_id = $2
+    and "status" <> 'removed'
+returning "id", "trip_id", "email", "role", "created_at", "locale", "name", "status", "responded_at", "removed_at";
+
+-- name: InsertOutboxMessage :exec
+insert into outbox
+    ( "kind", "payload" ) values
+    ( $1, $2 );
+
+-- name: ClaimOutboxMessages :many
+update outbox
+set
+    "next_attempt_at" = sqlc.arg(leased_until)
+where
+    id in (
+        select "id"
+        from outbox
+        where
+            status = 'pending'
+            and next_attempt_at <= now()
+        order by "next_attempt_at", "id"
+        limit sqlc.arg(batch_size)
+        for update skip locked
+    )
+returning
+    "id",
+    "kind",
+    "payload",
+    "status",
+    "attempts",
+    "next_attempt_at",
+    "last_error",
+    "sent_at",
+    "created_at";
+
+-- name: MarkOutboxMessageSent :exec
+update outbox
+set
+    "status" = 'sent',
+    "attempts" = "attempts" + 1,
+    "last_error" = null,
+    "sent_at" = now()
+where
+    id = $1
+    and status = 'pending';
+
+-- name: RetryOutboxMessage :exec
+update outbox
+set
+    "attempts" = "attempts" + 1,
+    "last_error" = $2,
+    "next_attempt_at" = $3
+where
+    id = $1
+    and status = 'pending';
+
+-- name: MarkOutboxMessageDead :exec
+update outbox
+set
+    "status" = 'dead',
+    "attempts" = "attempts" + 1,
+    "last_error" = $2
+where
+    id = $1
+    and status = 'pending';
+
+-- name: UpsertCalendarSubscription :exec
+insert into calendar_subscriptions
+    ( "trip_id", "user_id", "token_hash" ) values
+    ( $1, $2, $3 )
+on conflict ("trip_id", "user_id") do update
+set
+    "token_hash" = excluded."token_hash",
+    "created_at" = now();
+
+-- name: DeleteCalendarSubscription :execrows
+delete from calendar_subscriptions
+where
+    trip_id = $1
+    and user_id = $2;
+
+-- name: GetCalendarSubscriptionUser :one
+select
+    users."id",
+    users."email",
+    users."name",
+    users."password_hash",
+    users."created_at",
+    users."locale"
+from calendar_subscriptions
+join users on users.id = calendar_subscriptions.user_id
+where
+    calendar_subscriptions.trip_id = $1
+    and calendar_subscriptions.token_hash = $2;
+
+
+-- name: GetTripActivity :one
+select
+    "id", 
+    "trip_id", 
+    "title", 
+    "occurs_at",
+    "time_zone",
+    "ends_at",
+    "location",
+    "latitude",
+    "longitude",
+    "category",
+    "notes"
+from activities
+where
+    id = $1
+    and trip_id = $2;
+
+-- name: InsertExpense :one
+insert into expenses
+    ( "trip_id", "payer_id", "activity_id", "description", "amount", "currency", "split" ) values
+    ( $1, $2, $3, $4, $5, $6, $7 )
+returning "id";
+
+-- name: InsertExpenseShares :copyfrom
+insert into expense_shares
+    ( "expense_id", "participant_id", "shares", "amount" ) values
+    ( $1, $2, $3, $4 );
+
+-- name: ListTripExpenses :many
+select
+    "id",
+    "trip_id",
+    "payer_id",
+    "activity_id",
+    "description",
+    "amount",
+    "currency",
+    "split",
+    "created_at"
+from expenses
+where
+    trip_id = sqlc.arg(trip_id)
+    and (
+        sqlc.narg(after_created_at)::timestamptz is null
+        or ("created_at", "id") > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid)
+    )
+order by "created_at", "id"
+limit sqlc.arg(row_limit);
+
+-- name: GetExpenseShares :many
+select
+    "expense_id",
+    "participant_id",
+    "shares",
+    "amount"
+from expense_shares
+where
+    expense_id = any(sqlc.arg(expense_ids)::uuid[])
+order by "expense_id", "participant_id";
+
+-- name: DeleteExpense :execrows
+delete from expenses
+where
+    id = $1
+    and trip_id = $2;
+
+-- name: GetTripPaidTotals :many
+select
+    "payer_id" as "participant_id",
+    "currency",
+    sum("amount")::numeric as "total"
+from expenses
+where
+    trip_id = $1
+group by "payer_id", "currency";
+
+-- name: GetTripOwedTotals :many
+select
+    expense_shares."participant_id",
+    expenses."currency",
+    sum(expense_shares."amount")::numeric as "total"
+from expense_shares
+join expenses on expenses.id = expense_shares.expense_id
+where
+    expenses.trip_id = $1
+group by expense_shares."participant_id", expenses."currency";
+
+-- name: UpsertBudget :exec
+insert into budgets
+    ( "trip_id", "currency", "total" ) values
+    ( $1, $2, $3 )
+on conflict ("trip_id") do update
+set
+    "currency" = excluded."currency",
+    "total" = excluded."total",
+    "updated_at" = now();
+
+-- name: DeleteBudgetCategories :exec
+delete from budget_categories
+where
+    trip_id = $1;
+
+-- name: InsertBudgetCategories :copyfrom
+insert into budget_categories
+    ( "trip_id", "category", "amount" ) values
+    ( $1, $2, $3 );
+
+-- name: GetBudget :one
+select
+    "trip_id",
+    "currency",
+    "total",
+    "updated_at"
+from budgets
+where
+    trip_id = $1;
+
+-- name: GetBudgetCategories :many
+select
+    "trip_id",
+    "category",
+    "amount"
+from budget_categories
+where
+    trip_id = $1
+order by "category";
+
+-- name: GetTripSpending :many
+select
+    coalesce(activities."category", 'other')::varchar as "category",
+    expenses."currency",
+    (expenses."created_at" at time zone trips."time_zone")::date as "spent_on",
+    sum(expenses."amount")::numeric as "total"
+from expenses
+join trips on trips.id = expenses.trip_id
+left join activities on activities.id = expenses.activity_id
+where
+    expenses.trip_id = $1
+group by 1, 2, 3
+order by 1, 2, 3;
+
+-- name: GetExchangeRates :many
+select distinct on ("base", "quote")
+    "base",
+    "quote",
+    "valid_on",
+    "rate"
+from exchange_rates
+where
+    ("base" = any(sqlc.arg(currencies)::text[]) or "quote" = any(sqlc.arg(currencies)::text[]))
+    and "valid_on" <= sqlc.arg(valid_on)
+order by "base", "quote", "valid_on" desc;
+
+-- name: UpsertExchangeRate :exec
+insert into exchange_rates
+    ( "base", "quote", "valid_on", "rate" ) values
+    ( $1, $2, $3, $4 )
+on conflict ("base", "quote", "valid_on") do update
+set
+    "rate" = excluded."rate";
+
+-- name: ClaimInvitationReminders :many
+select
+    participants."id",
+    participants."trip_id"
+from participants
+join trips on trips.id = participants.trip_id
+join invitation_reminders on invitation_reminders.participant_id = participants.id
+where
+    participants."status" = 'invited'
+    and participants."role" <> 'owner'
+    and trips."is_confirmed"
+    and trips."starts_at" > now()
+    and invitation_reminders."reminders_sent" = sqlc.arg(reminders_sent)
+    and invitation_reminders."invited_at" <= sqlc.arg(invited_before)
+    and coalesce(invitation_reminders."reminded_at", invitation_reminders."invited_at") <= sqlc.arg(reminded_before)
+order by participants."id"
+limit sqlc.arg(row_limit)
+for update of participants skip locked;
+
+-- name: MarkInvitationReminded :exec
+update invitation_reminders
+set
+    "reminders_sent" = "reminders_sent" + 1,
+    "reminded_at" = now()
+where
+    participant_id = $1;
+
+-- name: ResetInvitationReminders :exec
+insert into invitation_reminders
+    ( "participant_id", "invited_at", "reminders_sent", "reminded_at" ) values
+    ( $1, now(), 0, null )
+on conflict ("participant_id") do update
+set
+    "invited_at" = now(),
+    "reminders_sent" = 0,
+    "reminded_at" = null;
+
+-- name: ExpireInvitations :execrows
+update participants
+set
+    "status" = 'expired'
+from trips
+where
+    trips.id = participants.trip_id
+    and participants."status" = 'invited'
+    and participants."role" <> 'owner'
+    and trips."is_confirmed"
+    and (
+        trips."starts_at" <= now()
+        or (
+            sqlc.narg(invited_before)::timestamptz is not null
+            and exists (
+                select 1
+                from invitation_reminders
+                where
+                    invitation_reminders.participant_id = participants.id
+                    and invitation_reminders."invited_at" <= sqlc.narg(invited_before)::timestamptz
+            )
+        )
+    );
+
+-- name: ClaimPendingSummaries :many
+select
+    trips."id",
+    trips."starts_at"
+from trips
+where
+    trips."is_confirmed"
+    and trips."starts_at" > now()
+    and trips."starts_at" <= sqlc.arg(starts_before)
+    and not exists (
+        select 1
+        from pending_summaries
+        where
+            pending_summaries.trip_id = trips.id
+            and pending_summaries."starts_at" = trips."starts_at"
+    )
+    and exists (
+        select 1
+        from participants
+        where
+            participants.trip_id = trips.id
+            and participants."status" in ('invited', 'maybe')
+    )
+order by trips."starts_at", trips."id"
+limit sqlc.arg(row_limit)
+for update of trips skip locked;
+
+-- name: MarkPendingSummarySent :exec
+insert into pending_summaries
+    ( "trip_id", "starts_at", "sent_at" ) values
+    ( $1, $2, now() )
+on conflict ("trip_id") do update
+set
+    "starts_at" = excluded."starts_at",
+    "sent_at" = excluded."sent_at";
+
+-- name: ClaimDailyDigests :many
+select
+    trips."id",
+    (now() at time zone trips."time_zone")::date as sent_on
+from trips
+where
+    trips."is_confirmed"
+    and (now() at time zone trips."time_zone")::time >= sqlc.arg(send_after)::time
+    and (now() at time zone trips."time_zone")::date + 1
+        between (trips."starts_at" at time zone trips."time_zone")::date
+        and (trips."ends_at" at time zone trips."time_zone")::date
+    and not exists (
+        select 1
+        from trip_digests
+        where
+            trip_digests.trip_id = trips.id
+            and trip_digests."kind" = 'daily'
+            and trip_digests."sent_on" = (now() at time zone trips."time_zone")::date
+    )
+order by trips."id"
+limit sqlc.arg(row_limit)
+for update of trips skip locked;
+
+-- name: ClaimTripCountdowns :many
+select
+    trips."id",
+    (now() at time zone trips."time_zone")::date as sent_on,
+    ((trips."starts_at" at time zone trips."time_zone")::date - (now() at time zone trips."time_zone")::date)::integer as days
+from trips
+where
+    trips."is_confirmed"
+    and (now() at time zone trips."time_zone")::time >= sqlc.arg(send_after)::time
+    and (trips."starts_at" at time zone trips."time_zone")::date - (now() at time zone trips."time_zone")::date = any(sqlc.arg(days)::integer[])
+    and not exists (
+        select 1
+        from trip_digests
+        where
+            trip_digests.trip_id = trips.id
+            and trip_digests."kind" = 'countdown'
+            and trip_digests."sent_on" = (now() at time zone trips."time_zone")::date
+    )
+order by trips."id"
+limit sqlc.arg(row_limit)
+for update of trips skip locked;
+
+-- name: MarkTripDigestSent :exec
+insert into trip_digests
+    ( "trip_id", "kind", "sent_on", "sent_at" ) values
+    ( $1, $2, $3, now() )
+on conflict ("trip_id", "kind", "sent_on") do nothing;
+
+-- name: ListDigestOptOuts :many
+select
+    digest_opt_outs."participant_id"
+from digest_opt_outs
+join participants on participants.id = digest_opt_outs.participant_id
+where
+    participants.trip_id = $1;
+
+-- name: OptOutOfDigests :exec
+insert into digest_opt_outs
+    ( "participant_id" ) values
+    ( $1 )
+on conflict ("participant_id") do nothing;
+
+-- name: OptInToDigests :exec
+delete from digest_opt_outs
+where
+    participant_id = $1;
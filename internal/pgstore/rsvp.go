@@ -0,0 +1,31 @@
+package pgstore
+
+// Answers of a participant to the invitation to a trip, kept in the status
+// column. Participants start invited, and removing them from the trip only
+// marks them so, which keeps the expenses they took part in. Invitations left
+// unanswered past their deadline are expired.
+const (
+	RSVPInvited  = "invited"
+	RSVPAccepted = "accepted"
+	RSVPDeclined = "declined"
+	RSVPMaybe    = "maybe"
+	RSVPRemoved  = "removed"
+	RSVPExpired  = "expired"
+)
+
+// Accepted tells whether the participant confirmed they are taking part in
+// the trip.
+func (p Participant) Accepted() bool {
+	return p.Status == RSVPAccepted
+}
+
+// Removed tells whether the participant was removed from the trip.
+func (p Participant) Removed() bool {
+	return p.Status == RSVPRemoved
+}
+
+// Owns tells whether the participant is the owner of the trip, who accepted
+// it when creating it and never answers an invitation.
+func (p Participant) Owns() bool {
+	return p.Role == "owner"
+}
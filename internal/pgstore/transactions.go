@@ -4,50 +4,888 @@ import (
 	"context"
 	"fmt"
 	"planner-go/internal/api/spec"
+	"planner-go/internal/magiclink"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func (q *Queries) CreateTrip(ctx context.Context, pool *pgxpool.Pool, params spec.CreateTripRequest) (uuid.UUID, error) {
+func (q *Queries) CreateTrip(ctx context.Context, pool *pgxpool.Pool, owner User, params spec.CreateTripRequest) (uuid.UUID, error) {
 	tx, err := pool.Begin(ctx)
 
 	if err != nil {
-		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for CreatTrip: %w", err)
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for CreateTrip: %w", err)
 	}
 
 	defer tx.Rollback(ctx)
 
 	qtx := q.WithTx(tx)
 
+	timeZone := DefaultTimeZone
+	if params.TimeZone != nil {
+		timeZone = *params.TimeZone
+	}
+
 	tripId, err := qtx.InsertTrip(ctx, InsertTripParams{
 		Destination: params.Destination,
-		OwnerEmail:  string(params.OwnerEmail),
-		OwnerName:   params.OwnerName,
-		StartsAt:    pgtype.Timestamp{Valid: true, Time: params.StartsAt},
-		EndsAt:      pgtype.Timestamp{Valid: true, Time: params.EndsAt},
+		OwnerEmail:  owner.Email,
+		OwnerName:   owner.Name,
+		StartsAt:    pgtype.Timestamptz{Valid: true, Time: params.StartsAt},
+		EndsAt:      pgtype.Timestamptz{Valid: true, Time: params.EndsAt},
+		TimeZone:    timeZone,
 	})
 
 	if err != nil {
-		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert trip for CreatTrip: %w", err)
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert trip for CreateTrip: %w", err)
 	}
 
-	participants := make([]InviteParticipantsToTripParams, len(params.EmailsToInvite))
-	for i, eti := range params.EmailsToInvite {
-		participants[i] = InviteParticipantsToTripParams{
-			TripID: tripId,
-			Email:  string(eti),
+	if err := qtx.AddTripOwner(ctx, AddTripOwnerParams{
+		TripID: tripId,
+		Email:  owner.Email,
+		Name:   pgtype.Text{String: owner.Name, Valid: true},
+		Locale: owner.Locale,
+	}); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to add owner for CreateTrip: %w", err)
+	}
+
+	// the owner is already a participant, and every other address is invited once
+	invited := map[string]bool{strings.ToLower(owner.Email): true}
+	participants := make([]InviteParticipantsToTripParams, 0, len(params.EmailsToInvite))
+	for _, eti := range params.EmailsToInvite {
+		email := string(eti)
+		if invited[strings.ToLower(email)] {
+			continue
 		}
+		invited[strings.ToLower(email)] = true
+
+		participants = append(participants, InviteParticipantsToTripParams{
+			TripID: tripId,
+			Email:  email,
+			Locale: owner.Locale,
+		})
 	}
 
 	if _, err := qtx.InviteParticipantsToTrip(ctx, participants); err != nil {
-		return uuid.UUID{}, fmt.Errorf("pgstore: failed to invite participants for CreatTrip: %w", err)
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to invite participants for CreateTrip: %w", err)
+	}
+
+	token, err := qtx.issueToken(ctx, magiclink.PurposeTrip, tripId, TokenTTL)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to create token for CreateTrip: %w", err)
+	}
+
+	if err := qtx.enqueue(ctx, OutboxTripCreated, TripCreatedMessage{TripID: tripId, Token: token}); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to enqueue email for CreateTrip: %w", err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for CreatTrip: %w", err)
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit trx for CreateTrip: %w", err)
 	}
 
 	return tripId, nil
 }
+
+func (q *Queries) ConfirmTripWithToken(ctx context.Context, pool *pgxpool.Pool, tokenId uuid.UUID) (uuid.UUID, bool, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, false, fmt.Errorf("pgstore: failed to begin trx for ConfirmTripWithToken: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	tripId, err := qtx.ConsumeConfirmationToken(ctx, ConsumeConfirmationTokenParams{
+		ID:      tokenId,
+		Purpose: magiclink.PurposeTrip,
+	})
+	if err != nil {
+		return uuid.UUID{}, false, fmt.Errorf("pgstore: failed to consume token for ConfirmTripWithToken: %w", err)
+	}
+
+	confirmed, err := qtx.ConfirmTrip(ctx, tripId)
+	if err != nil {
+		return uuid.UUID{}, false, fmt.Errorf("pgstore: failed to confirm trip for ConfirmTripWithToken: %w", err)
+	}
+
+	if confirmed > 0 {
+		if err := qtx.enqueueTripInvitations(ctx, tripId); err != nil {
+			return uuid.UUID{}, false, fmt.Errorf("pgstore: failed to enqueue emails for ConfirmTripWithToken: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, false, fmt.Errorf("pgstore: failed to commit trx for ConfirmTripWithToken: %w", err)
+	}
+
+	return tripId, confirmed > 0, nil
+}
+
+func (q *Queries) ConfirmParticipantWithToken(ctx context.Context, pool *pgxpool.Pool, tokenId uuid.UUID) (uuid.UUID, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for ConfirmParticipantWithToken: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	participantId, err := qtx.ConsumeConfirmationToken(ctx, ConsumeConfirmationTokenParams{
+		ID:      tokenId,
+		Purpose: magiclink.PurposeParticipant,
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to consume token for ConfirmParticipantWithToken: %w", err)
+	}
+
+	accepted, err := qtx.SetParticipantStatus(ctx, SetParticipantStatusParams{
+		ID:     participantId,
+		Status: RSVPAccepted,
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to confirm participant for ConfirmParticipantWithToken: %w", err)
+	}
+
+	if accepted == 0 {
+		participant, err := qtx.GetParticipant(ctx, participantId)
+		if err != nil {
+			return uuid.UUID{}, fmt.Errorf("pgstore: failed to get participant for ConfirmParticipantWithToken: %w", err)
+		}
+		if participant.Status == RSVPExpired {
+			return uuid.UUID{}, fmt.Errorf("pgstore: failed to confirm participant for ConfirmParticipantWithToken: %w", ErrInvitationExpired)
+		}
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to confirm participant for ConfirmParticipantWithToken: %w", ErrParticipantRemoved)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit trx for ConfirmParticipantWithToken: %w", err)
+	}
+
+	return participantId, nil
+}
+
+func (q *Queries) ConfirmTripAndNotify(ctx context.Context, pool *pgxpool.Pool, tripId uuid.UUID) (bool, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("pgstore: failed to begin trx for ConfirmTripAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	confirmed, err := qtx.ConfirmTrip(ctx, tripId)
+	if err != nil {
+		return false, fmt.Errorf("pgstore: failed to confirm trip for ConfirmTripAndNotify: %w", err)
+	}
+
+	if confirmed == 0 {
+		return false, nil
+	}
+
+	if err := qtx.enqueueTripInvitations(ctx, tripId); err != nil {
+		return false, fmt.Errorf("pgstore: failed to enqueue emails for ConfirmTripAndNotify: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("pgstore: failed to commit trx for ConfirmTripAndNotify: %w", err)
+	}
+
+	return true, nil
+}
+
+func (q *Queries) InviteParticipantAndNotify(ctx context.Context, pool *pgxpool.Pool, params InviteParticipantToTripParams) (uuid.UUID, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for InviteParticipantAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	participantId, err := qtx.InviteParticipantToTrip(ctx, params)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return uuid.UUID{}, ErrParticipantExists
+		}
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to invite participant for InviteParticipantAndNotify: %w", err)
+	}
+
+	if err := qtx.enqueueInvitation(ctx, params.TripID, participantId); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to enqueue email for InviteParticipantAndNotify: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit trx for InviteParticipantAndNotify: %w", err)
+	}
+
+	return participantId, nil
+}
+
+func (q *Queries) InviteParticipantsAndNotify(ctx context.Context, pool *pgxpool.Pool, tripId uuid.UUID, invites []InviteParticipantsToTripParams) ([]string, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to begin trx for InviteParticipantsAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	participants, err := qtx.GetParticipants(ctx, tripId)
+	if err != nil {
+		return nil, fmt.Errorf("pgstore: failed to get participants for InviteParticipantsAndNotify: %w", err)
+	}
+
+	existing := make(map[string]Participant, len(participants))
+	for _, participant := range participants {
+		existing[strings.ToLower(participant.Email)] = participant
+	}
+
+	// addresses already in the trip are left alone, except for those removed
+	// before or whose invitation expired, which are invited again keeping
+	// their expenses
+	var invited []string
+	var rows []InviteParticipantsToTripParams
+	var notify []uuid.UUID
+	for _, invite := range invites {
+		participant, ok := existing[strings.ToLower(invite.Email)]
+		if !ok {
+			rows = append(rows, invite)
+			continue
+		}
+
+		reinvited, err := qtx.ReinviteParticipant(ctx, ReinviteParticipantParams{
+			ID:     participant.ID,
+			Name:   invite.Name,
+			Locale: invite.Locale,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pgstore: failed to reinvite participant for InviteParticipantsAndNotify: %w", err)
+		}
+		if reinvited == 0 {
+			continue
+		}
+
+		invited = append(invited, invite.Email)
+		notify = append(notify, participant.ID)
+	}
+
+	if _, err := qtx.InviteParticipantsToTrip(ctx, rows); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrParticipantExists
+		}
+		return nil, fmt.Errorf("pgstore: failed to invite participants for InviteParticipantsAndNotify: %w", err)
+	}
+
+	// the copy does not return the new ids, so they are read back
+	if len(rows) > 0 {
+		participants, err := qtx.GetParticipants(ctx, tripId)
+		if err != nil {
+			return nil, fmt.Errorf("pgstore: failed to get participants for InviteParticipantsAndNotify: %w", err)
+		}
+
+		ids := make(map[string]uuid.UUID, len(participants))
+		for _, participant := range participants {
+			ids[strings.ToLower(participant.Email)] = participant.ID
+		}
+
+		for _, row := range rows {
+			invited = append(invited, row.Email)
+			notify = append(notify, ids[strings.ToLower(row.Email)])
+		}
+	}
+
+	for _, participantId := range notify {
+		if err := qtx.enqueueInvitation(ctx, tripId, participantId); err != nil {
+			return nil, fmt.Errorf("pgstore: failed to enqueue email for InviteParticipantsAndNotify: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("pgstore: failed to commit trx for InviteParticipantsAndNotify: %w", err)
+	}
+
+	return invited, nil
+}
+
+func (q *Queries) ReinviteParticipantAndNotify(ctx context.Context, pool *pgxpool.Pool, tripId uuid.UUID, params ReinviteParticipantParams) (bool, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("pgstore: failed to begin trx for ReinviteParticipantAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	reinvited, err := qtx.ReinviteParticipant(ctx, params)
+	if err != nil {
+		return false, fmt.Errorf("pgstore: failed to reinvite participant for ReinviteParticipantAndNotify: %w", err)
+	}
+
+	if reinvited == 0 {
+		return false, nil
+	}
+
+	// reminders and the deadline count from the new invitation
+	if err := qtx.enqueueInvitation(ctx, tripId, params.ID); err != nil {
+		return false, fmt.Errorf("pgstore: failed to enqueue email for ReinviteParticipantAndNotify: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("pgstore: failed to commit trx for ReinviteParticipantAndNotify: %w", err)
+	}
+
+	return true, nil
+}
+
+func (q *Queries) DeleteTripAndNotify(ctx context.Context, pool *pgxpool.Pool, tripId uuid.UUID) (Trip, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to begin trx for DeleteTripAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	// participants are removed along with the trip, so they are loaded first
+	participants, err := qtx.GetParticipants(ctx, tripId)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to get participants for DeleteTripAndNotify: %w", err)
+	}
+
+	trip, err := qtx.DeleteTrip(ctx, tripId)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to delete trip for DeleteTripAndNotify: %w", err)
+	}
+
+	if err := qtx.enqueue(ctx, OutboxTripDeleted, TripDeletedMessage{
+		Trip:         trip,
+		Participants: participants,
+	}); err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to enqueue email for DeleteTripAndNotify: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to commit trx for DeleteTripAndNotify: %w", err)
+	}
+
+	return trip, nil
+}
+
+// UpdateTripChecked updates a trip once check accepts the trip, as it is
+// before the update, and its activities. The trip is locked until it is
+// updated, so no activity can be written in between and slip past check. An
+// error from check is returned as is.
+func (q *Queries) UpdateTripChecked(ctx context.Context, pool *pgxpool.Pool, params UpdateTripParams, check func(Trip, []Activity) error) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for UpdateTripChecked: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.checkActivities(ctx, params.ID, check); err != nil {
+		return err
+	}
+
+	if err := qtx.UpdateTrip(ctx, params); err != nil {
+		return fmt.Errorf("pgstore: failed to update trip for UpdateTripChecked: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit trx for UpdateTripChecked: %w", err)
+	}
+
+	return nil
+}
+
+// CreateActivityChecked creates an activity once check accepts the trip and
+// the activities it already has, as UpdateTripChecked does.
+func (q *Queries) CreateActivityChecked(ctx context.Context, pool *pgxpool.Pool, params CreateActivityParams, check func(Trip, []Activity) error) (uuid.UUID, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for CreateActivityChecked: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.checkActivities(ctx, params.TripID, check); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	activityId, err := qtx.CreateActivity(ctx, params)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to create activity for CreateActivityChecked: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit trx for CreateActivityChecked: %w", err)
+	}
+
+	return activityId, nil
+}
+
+// UpdateActivityChecked updates an activity once check accepts the trip and
+// its activities, as UpdateTripChecked does.
+func (q *Queries) UpdateActivityChecked(ctx context.Context, pool *pgxpool.Pool, params UpdateActivityParams, check func(Trip, []Activity) error) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin trx for UpdateActivityChecked: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.checkActivities(ctx, params.TripID, check); err != nil {
+		return 0, err
+	}
+
+	updated, err := qtx.UpdateActivity(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to update activity for UpdateActivityChecked: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit trx for UpdateActivityChecked: %w", err)
+	}
+
+	return updated, nil
+}
+
+// checkActivities locks the trip and hands it to check along with its
+// activities.
+func (q *Queries) checkActivities(ctx context.Context, tripId uuid.UUID, check func(Trip, []Activity) error) error {
+	trip, err := q.LockTrip(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to lock trip: %w", err)
+	}
+
+	activities, err := q.GetTripActivities(ctx, tripId)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to get trip activities: %w", err)
+	}
+
+	return check(trip, activities)
+}
+
+func (q *Queries) DeleteActivityAndNotify(ctx context.Context, pool *pgxpool.Pool, params DeleteActivityParams) (Activity, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return Activity{}, fmt.Errorf("pgstore: failed to begin trx for DeleteActivityAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	activity, err := qtx.DeleteActivity(ctx, params)
+	if err != nil {
+		return Activity{}, fmt.Errorf("pgstore: failed to delete activity for DeleteActivityAndNotify: %w", err)
+	}
+
+	if err := qtx.enqueue(ctx, OutboxActivityDeleted, ActivityDeletedMessage{Activity: activity}); err != nil {
+		return Activity{}, fmt.Errorf("pgstore: failed to enqueue email for DeleteActivityAndNotify: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Activity{}, fmt.Errorf("pgstore: failed to commit trx for DeleteActivityAndNotify: %w", err)
+	}
+
+	return activity, nil
+}
+
+func (q *Queries) DeleteTripLinkAndNotify(ctx context.Context, pool *pgxpool.Pool, params DeleteTripLinkParams) (Link, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return Link{}, fmt.Errorf("pgstore: failed to begin trx for DeleteTripLinkAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	link, err := qtx.DeleteTripLink(ctx, params)
+	if err != nil {
+		return Link{}, fmt.Errorf("pgstore: failed to delete link for DeleteTripLinkAndNotify: %w", err)
+	}
+
+	if err := qtx.enqueue(ctx, OutboxLinkDeleted, LinkDeletedMessage{Link: link}); err != nil {
+		return Link{}, fmt.Errorf("pgstore: failed to enqueue email for DeleteTripLinkAndNotify: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Link{}, fmt.Errorf("pgstore: failed to commit trx for DeleteTripLinkAndNotify: %w", err)
+	}
+
+	return link, nil
+}
+
+func (q *Queries) RemoveParticipantAndNotify(ctx context.Context, pool *pgxpool.Pool, trip Trip, params RemoveParticipantParams) (Participant, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return Participant{}, fmt.Errorf("pgstore: failed to begin trx for RemoveParticipantAndNotify: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	participant, err := qtx.RemoveParticipant(ctx, params)
+	if err != nil {
+		return Participant{}, fmt.Errorf("pgstore: failed to remove participant for RemoveParticipantAndNotify: %w", err)
+	}
+
+	if err := qtx.enqueue(ctx, OutboxParticipantRemoved, ParticipantRemovedMessage{
+		Trip:        trip,
+		Participant: participant,
+	}); err != nil {
+		return Participant{}, fmt.Errorf("pgstore: failed to enqueue email for RemoveParticipantAndNotify: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Participant{}, fmt.Errorf("pgstore: failed to commit trx for RemoveParticipantAndNotify: %w", err)
+	}
+
+	return participant, nil
+}
+
+func (q *Queries) CreateExpense(ctx context.Context, pool *pgxpool.Pool, params InsertExpenseParams, shares []InsertExpenseSharesParams) (uuid.UUID, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for CreateExpense: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	expenseId, err := qtx.InsertExpense(ctx, params)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert expense for CreateExpense: %w", err)
+	}
+
+	rows := make([]InsertExpenseSharesParams, len(shares))
+	for i, share := range shares {
+		rows[i] = share
+		rows[i].ExpenseID = expenseId
+	}
+
+	if _, err := qtx.InsertExpenseShares(ctx, rows); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert shares for CreateExpense: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit trx for CreateExpense: %w", err)
+	}
+
+	return expenseId, nil
+}
+
+func (q *Queries) SetBudget(ctx context.Context, pool *pgxpool.Pool, params UpsertBudgetParams, categories []InsertBudgetCategoriesParams) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for SetBudget: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	if err := qtx.UpsertBudget(ctx, params); err != nil {
+		return fmt.Errorf("pgstore: failed to upsert budget for SetBudget: %w", err)
+	}
+
+	// the categories sent replace all of the previous ones
+	if err := qtx.DeleteBudgetCategories(ctx, params.TripID); err != nil {
+		return fmt.Errorf("pgstore: failed to delete categories for SetBudget: %w", err)
+	}
+
+	if _, err := qtx.InsertBudgetCategories(ctx, categories); err != nil {
+		return fmt.Errorf("pgstore: failed to insert categories for SetBudget: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit trx for SetBudget: %w", err)
+	}
+
+	return nil
+}
+
+func (q *Queries) TransferTripOwnership(ctx context.Context, pool *pgxpool.Pool, trip Trip, participant Participant, owner User) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for TransferTripOwnership: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	// the previous owner stays on the trip as an editor
+	if err := qtx.DemoteTripOwner(ctx, trip.ID); err != nil {
+		return fmt.Errorf("pgstore: failed to demote owner for TransferTripOwnership: %w", err)
+	}
+
+	if err := qtx.PromoteTripOwner(ctx, participant.ID); err != nil {
+		return fmt.Errorf("pgstore: failed to promote participant for TransferTripOwnership: %w", err)
+	}
+
+	if err := qtx.UpdateTripOwner(ctx, UpdateTripOwnerParams{
+		OwnerEmail: owner.Email,
+		OwnerName:  owner.Name,
+		ID:         trip.ID,
+	}); err != nil {
+		return fmt.Errorf("pgstore: failed to update trip for TransferTripOwnership: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit trx for TransferTripOwnership: %w", err)
+	}
+
+	return nil
+}
+
+func (q *Queries) RemindInvitations(ctx context.Context, pool *pgxpool.Pool, params ClaimInvitationRemindersParams) (int, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin trx for RemindInvitations: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	// the claimed rows stay locked until the reminders are queued, so other
+	// instances skip them instead of reminding them twice
+	due, err := qtx.ClaimInvitationReminders(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to claim reminders for RemindInvitations: %w", err)
+	}
+
+	for _, participant := range due {
+		if err := qtx.MarkInvitationReminded(ctx, participant.ID); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to mark reminder for RemindInvitations: %w", err)
+		}
+
+		if err := qtx.enqueueParticipantMessage(ctx, OutboxInvitationReminder, participant.TripID, participant.ID); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to enqueue email for RemindInvitations: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit trx for RemindInvitations: %w", err)
+	}
+
+	return len(due), nil
+}
+
+func (q *Queries) SummarizePendingInvitations(ctx context.Context, pool *pgxpool.Pool, params ClaimPendingSummariesParams) (int, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin trx for SummarizePendingInvitations: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	trips, err := qtx.ClaimPendingSummaries(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to claim trips for SummarizePendingInvitations: %w", err)
+	}
+
+	for _, trip := range trips {
+		// the start date is kept so that moving the trip sends a new summary
+		if err := qtx.MarkPendingSummarySent(ctx, MarkPendingSummarySentParams{
+			TripID:   trip.ID,
+			StartsAt: trip.StartsAt,
+		}); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to mark summary for SummarizePendingInvitations: %w", err)
+		}
+
+		if err := qtx.enqueue(ctx, OutboxPendingInvitations, TripMessage{TripID: trip.ID}); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to enqueue email for SummarizePendingInvitations: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit trx for SummarizePendingInvitations: %w", err)
+	}
+
+	return len(trips), nil
+}
+
+func (q *Queries) QueueDailyDigests(ctx context.Context, pool *pgxpool.Pool, params ClaimDailyDigestsParams) (int, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin trx for QueueDailyDigests: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	trips, err := qtx.ClaimDailyDigests(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to claim trips for QueueDailyDigests: %w", err)
+	}
+
+	for _, trip := range trips {
+		if err := qtx.MarkTripDigestSent(ctx, MarkTripDigestSentParams{
+			TripID: trip.ID,
+			Kind:   DigestDaily,
+			SentOn: trip.SentOn,
+		}); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to mark digest for QueueDailyDigests: %w", err)
+		}
+
+		// the digest is about the day after the one it is sent on
+		if err := qtx.enqueue(ctx, OutboxDailyDigest, DailyDigestMessage{
+			TripID: trip.ID,
+			Day:    trip.SentOn.Time.AddDate(0, 0, 1).Format(time.DateOnly),
+		}); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to enqueue email for QueueDailyDigests: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit trx for QueueDailyDigests: %w", err)
+	}
+
+	return len(trips), nil
+}
+
+func (q *Queries) QueueTripCountdowns(ctx context.Context, pool *pgxpool.Pool, params ClaimTripCountdownsParams) (int, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin trx for QueueTripCountdowns: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	trips, err := qtx.ClaimTripCountdowns(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to claim trips for QueueTripCountdowns: %w", err)
+	}
+
+	for _, trip := range trips {
+		if err := qtx.MarkTripDigestSent(ctx, MarkTripDigestSentParams{
+			TripID: trip.ID,
+			Kind:   DigestCountdown,
+			SentOn: trip.SentOn,
+		}); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to mark countdown for QueueTripCountdowns: %w", err)
+		}
+
+		if err := qtx.enqueue(ctx, OutboxTripCountdown, TripCountdownMessage{
+			TripID: trip.ID,
+			Days:   trip.Days,
+		}); err != nil {
+			return 0, fmt.Errorf("pgstore: failed to enqueue email for QueueTripCountdowns: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit trx for QueueTripCountdowns: %w", err)
+	}
+
+	return len(trips), nil
+}
+
+// accountClaimTTL is how long the link to claim a placeholder account stays
+// valid.
+const accountClaimTTL = 24 * time.Hour
+
+func (q *Queries) RequestAccountClaim(ctx context.Context, pool *pgxpool.Pool, user User, params CreateUserParams) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin trx for RequestAccountClaim: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	// what was signed up with is only applied once the link sent to the
+	// address is opened, so nobody else can take over the trips it owns
+	token, err := qtx.issueToken(ctx, magiclink.PurposeAccount, user.ID, accountClaimTTL)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to create token for RequestAccountClaim: %w", err)
+	}
+
+	if err := qtx.CreateAccountClaim(ctx, CreateAccountClaimParams{
+		TokenID:      token.ID,
+		Name:         params.Name,
+		PasswordHash: params.PasswordHash,
+		Locale:       params.Locale,
+	}); err != nil {
+		return fmt.Errorf("pgstore: failed to create claim for RequestAccountClaim: %w", err)
+	}
+
+	if err := qtx.enqueue(ctx, OutboxAccountClaim, AccountClaimMessage{
+		UserID: user.ID,
+		Email:  user.Email,
+		Locale: params.Locale,
+		Token:  token,
+	}); err != nil {
+		return fmt.Errorf("pgstore: failed to enqueue email for RequestAccountClaim: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgstore: failed to commit trx for RequestAccountClaim: %w", err)
+	}
+
+	return nil
+}
+
+func (q *Queries) ClaimAccountWithToken(ctx context.Context, pool *pgxpool.Pool, tokenId uuid.UUID) (uuid.UUID, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin trx for ClaimAccountWithToken: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	userId, err := qtx.ConsumeConfirmationToken(ctx, ConsumeConfirmationTokenParams{
+		ID:      tokenId,
+		Purpose: magiclink.PurposeAccount,
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to consume token for ClaimAccountWithToken: %w", err)
+	}
+
+	// another link sent to the same address may have claimed it first
+	claimed, err := qtx.ClaimAccount(ctx, ClaimAccountParams{
+		TokenID: tokenId,
+		UserID:  userId,
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to claim account for ClaimAccountWithToken: %w", err)
+	}
+	if claimed == 0 {
+		return uuid.UUID{}, ErrAccountClaimed
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit trx for ClaimAccountWithToken: %w", err)
+	}
+
+	return userId, nil
+}
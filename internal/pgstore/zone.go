@@ -0,0 +1,29 @@
+package pgstore
+
+import "time"
+
+// DefaultTimeZone is the zone of trips created without one.
+const DefaultTimeZone = "UTC"
+
+// Zone returns the time zone of the trip. Zones are validated when they
+// are set, so UTC is only returned for a zone missing from the tz database.
+func (t Trip) Zone() *time.Location {
+	loc, err := time.LoadLocation(t.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Zone returns the time zone of the activity, which is that of its trip
+// unless it has its own.
+func (a Activity) Zone(trip Trip) *time.Location {
+	if !a.TimeZone.Valid {
+		return trip.Zone()
+	}
+	loc, err := time.LoadLocation(a.TimeZone.String)
+	if err != nil {
+		return trip.Zone()
+	}
+	return loc
+}
@@ -0,0 +1,194 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"planner-go/internal/pgstore"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	// PollInterval is how long the scheduler sleeps between two looks for
+	// invitations that are due.
+	PollInterval time.Duration
+	// Reminders are how long after an invitation was sent each reminder is,
+	// in increasing order. Empty sends none.
+	Reminders []time.Duration
+	// ExpireAfter is how long an invitation may go unanswered. Zero keeps it
+	// open until the trip starts, when every unanswered invitation expires.
+	ExpireAfter time.Duration
+	// SummaryBefore is how long before a trip starts its owner is told who
+	// has not accepted yet. Zero sends no summary.
+	SummaryBefore time.Duration
+	// BatchSize is how many reminders or summaries are queued at once.
+	BatchSize int32
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:  time.Minute,
+		Reminders:     []time.Duration{3 * 24 * time.Hour, 7 * 24 * time.Hour},
+		ExpireAfter:   14 * 24 * time.Hour,
+		SummaryBefore: 2 * 24 * time.Hour,
+		BatchSize:     50,
+	}
+}
+
+// LoadConfig reads the PLANNER_INVITATION_* variables over DefaultConfig.
+// Reminders are a comma-separated list of durations such as "72h,168h", and
+// "off" turns off the reminders, the expiry or the summary.
+func LoadConfig() (Config, error) {
+	cfg := DefaultConfig()
+
+	if v := os.Getenv("PLANNER_INVITATION_REMINDERS"); v != "" {
+		cfg.Reminders = nil
+		if v != "off" {
+			for _, s := range strings.Split(v, ",") {
+				d, err := time.ParseDuration(strings.TrimSpace(s))
+				if err != nil {
+					return Config{}, fmt.Errorf("reminder: invalid PLANNER_INVITATION_REMINDERS: %w", err)
+				}
+				cfg.Reminders = append(cfg.Reminders, d)
+			}
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"PLANNER_INVITATION_POLL_INTERVAL":  &cfg.PollInterval,
+		"PLANNER_INVITATION_EXPIRY":         &cfg.ExpireAfter,
+		"PLANNER_INVITATION_SUMMARY_BEFORE": &cfg.SummaryBefore,
+	}
+	for key, field := range durations {
+		v := os.Getenv(key)
+		switch v {
+		case "":
+		case "off":
+			*field = 0
+		default:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return Config{}, fmt.Errorf("reminder: invalid %s: %w", key, err)
+			}
+			*field = d
+		}
+	}
+
+	return cfg, cfg.validate()
+}
+
+func (cfg Config) validate() error {
+	if cfg.PollInterval <= 0 {
+		return errors.New("reminder: poll interval must be positive")
+	}
+	for i, d := range cfg.Reminders {
+		if d <= 0 {
+			return errors.New("reminder: reminders must be positive")
+		}
+		if i > 0 && d <= cfg.Reminders[i-1] {
+			return errors.New("reminder: reminders must be in increasing order")
+		}
+		if cfg.ExpireAfter > 0 && d >= cfg.ExpireAfter {
+			return errors.New("reminder: reminders must come before the invitation expires")
+		}
+	}
+	if cfg.ExpireAfter < 0 || cfg.SummaryBefore < 0 {
+		return errors.New("reminder: durations cannot be negative")
+	}
+	return nil
+}
+
+// Scheduler reminds the invitations nobody answered, expires them past their
+// deadline and tells the owners of trips about to start who is still pending.
+// What it sends goes through the outbox along with the state that keeps it
+// from being sent again, so restarts and other instances do not repeat it.
+type Scheduler struct {
+	pool   *pgxpool.Pool
+	store  *pgstore.Queries
+	logger *zap.Logger
+	cfg    Config
+}
+
+func NewScheduler(pool *pgxpool.Pool, logger *zap.Logger, cfg Config) *Scheduler {
+	return &Scheduler{pool, pgstore.New(pool), logger, cfg}
+}
+
+// Run looks for due invitations until ctx is done. A look that is under way
+// when ctx is done is finished first.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		s.tick(context.WithoutCancel(ctx), time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.cfg.PollInterval):
+		}
+	}
+}
+
+// tick expires the invitations first, so that none is reminded past its
+// deadline.
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	var invitedBefore pgtype.Timestamptz
+	if s.cfg.ExpireAfter > 0 {
+		invitedBefore = pgtype.Timestamptz{Time: now.Add(-s.cfg.ExpireAfter), Valid: true}
+	}
+
+	expired, err := s.store.ExpireInvitations(ctx, invitedBefore)
+	if err != nil {
+		s.logger.Error("Failed to expire invitations", zap.Error(err))
+	} else if expired > 0 {
+		s.logger.Info("Expired invitations", zap.Int64("participants", expired))
+	}
+
+	for i, after := range s.cfg.Reminders {
+		// a late reminder waits as long after the previous one as it would
+		// have, instead of following it at once
+		since := after
+		if i > 0 {
+			since -= s.cfg.Reminders[i-1]
+		}
+
+		if err := s.drain(func() (int, error) {
+			return s.store.RemindInvitations(ctx, s.pool, pgstore.ClaimInvitationRemindersParams{
+				RemindersSent:  int32(i),
+				InvitedBefore:  pgtype.Timestamptz{Time: now.Add(-after), Valid: true},
+				RemindedBefore: pgtype.Timestamptz{Time: now.Add(-since), Valid: true},
+				RowLimit:       s.cfg.BatchSize,
+			})
+		}); err != nil {
+			s.logger.Error("Failed to remind invitations", zap.Error(err), zap.Int("reminder", i+1))
+		}
+	}
+
+	if s.cfg.SummaryBefore > 0 {
+		if err := s.drain(func() (int, error) {
+			return s.store.SummarizePendingInvitations(ctx, s.pool, pgstore.ClaimPendingSummariesParams{
+				StartsBefore: pgtype.Timestamptz{Time: now.Add(s.cfg.SummaryBefore), Valid: true},
+				RowLimit:     s.cfg.BatchSize,
+			})
+		}); err != nil {
+			s.logger.Error("Failed to summarize pending invitations", zap.Error(err))
+		}
+	}
+}
+
+// drain calls batch until it handles less than a full batch.
+func (s *Scheduler) drain(batch func() (int, error)) error {
+	for {
+		n, err := batch()
+		if err != nil {
+			return err
+		}
+		if n < int(s.cfg.BatchSize) {
+			return nil
+		}
+	}
+}